@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"slices"
+
 	bootstrapv1 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta1"
 	"github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
 	"k8s.io/utils/ptr"
@@ -41,6 +43,8 @@ func (kcpv1beta1 *K0sControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		UpToDateReplicas:            new(kcpv1beta1.Status.UpdatedReplicas),
 		AvailableReplicas:           new(kcpv1beta1.Status.Replicas - kcpv1beta1.Status.UnavailableReplicas),
 		Conditions:                  kcpv1beta1.Status.Conditions,
+		LastRemediation:             kcpv1beta1.Status.LastRemediation.DeepCopy(),
+		Certificates:                slices.Clone(kcpv1beta1.Status.Certificates),
 	}
 	return nil
 }
@@ -54,6 +58,9 @@ func k0sControlPlaneSpecV1beta1ToV1beta2(spec K0sControlPlaneSpec) v1beta2.K0sCo
 		UpdateStrategy:           spec.UpdateStrategy,
 		Version:                  spec.Version,
 		KubeconfigSecretMetadata: spec.KubeconfigSecretMetadata,
+		MachineNamingStrategy:    spec.MachineNamingStrategy.DeepCopy(),
+		Rollout:                  *spec.Rollout.DeepCopy(),
+		RemediationStrategy:      spec.RemediationStrategy.DeepCopy(),
 	}
 }
 
@@ -70,6 +77,9 @@ func (kcpv1beta1 *K0sControlPlane) ConvertFrom(srcRaw conversion.Hub) error {
 		UpdateStrategy:           src.Spec.UpdateStrategy,
 		Version:                  src.Spec.Version,
 		KubeconfigSecretMetadata: src.Spec.KubeconfigSecretMetadata,
+		MachineNamingStrategy:    src.Spec.MachineNamingStrategy.DeepCopy(),
+		Rollout:                  *src.Spec.Rollout.DeepCopy(),
+		RemediationStrategy:      src.Spec.RemediationStrategy.DeepCopy(),
 	}
 	kcpv1beta1.Status = K0sControlPlaneStatus{
 		Ready:       ptr.Deref(src.Status.ReadyReplicas, 0) > 0,
@@ -84,6 +94,8 @@ func (kcpv1beta1 *K0sControlPlane) ConvertFrom(srcRaw conversion.Hub) error {
 		UnavailableReplicas:         ptr.Deref(src.Status.Replicas, 0),
 		ReadyReplicas:               ptr.Deref(src.Status.ReadyReplicas, 0),
 		Conditions:                  src.Status.Conditions,
+		LastRemediation:             src.Status.LastRemediation.DeepCopy(),
+		Certificates:                slices.Clone(src.Status.Certificates),
 	}
 	if src.Status.UpToDateReplicas != nil {
 		kcpv1beta1.Status.UpdatedReplicas = *src.Status.UpToDateReplicas
@@ -106,10 +118,13 @@ func (kcpv1beta1 *K0sControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) erro
 		Template: v1beta2.K0sControlPlaneTemplateResource{
 			ObjectMeta: kcpv1beta1.Spec.Template.ObjectMeta,
 			Spec: v1beta2.K0sControlPlaneTemplateResourceSpec{
-				K0sConfigSpec:   *configSpec.DeepCopy(),
-				MachineTemplate: kcpv1beta1.Spec.Template.Spec.MachineTemplate.DeepCopy(),
-				Version:         kcpv1beta1.Spec.Template.Spec.Version,
-				UpdateStrategy:  kcpv1beta1.Spec.Template.Spec.UpdateStrategy,
+				K0sConfigSpec:         *configSpec.DeepCopy(),
+				MachineTemplate:       kcpv1beta1.Spec.Template.Spec.MachineTemplate.DeepCopy(),
+				Version:               kcpv1beta1.Spec.Template.Spec.Version,
+				UpdateStrategy:        kcpv1beta1.Spec.Template.Spec.UpdateStrategy,
+				MachineNamingStrategy: kcpv1beta1.Spec.Template.Spec.MachineNamingStrategy.DeepCopy(),
+				Rollout:               *kcpv1beta1.Spec.Template.Spec.Rollout.DeepCopy(),
+				RemediationStrategy:   kcpv1beta1.Spec.Template.Spec.RemediationStrategy.DeepCopy(),
 			},
 		},
 	}
@@ -125,10 +140,13 @@ func (kcpv1beta1 *K0sControlPlaneTemplate) ConvertFrom(srcRaw conversion.Hub) er
 		Template: K0sControlPlaneTemplateResource{
 			ObjectMeta: src.Spec.Template.ObjectMeta,
 			Spec: K0sControlPlaneTemplateResourceSpec{
-				K0sConfigSpec:   *configSpec.DeepCopy(),
-				MachineTemplate: src.Spec.Template.Spec.MachineTemplate.DeepCopy(),
-				Version:         src.Spec.Template.Spec.Version,
-				UpdateStrategy:  src.Spec.Template.Spec.UpdateStrategy,
+				K0sConfigSpec:         *configSpec.DeepCopy(),
+				MachineTemplate:       src.Spec.Template.Spec.MachineTemplate.DeepCopy(),
+				Version:               src.Spec.Template.Spec.Version,
+				UpdateStrategy:        src.Spec.Template.Spec.UpdateStrategy,
+				MachineNamingStrategy: src.Spec.Template.Spec.MachineNamingStrategy.DeepCopy(),
+				Rollout:               *src.Spec.Template.Spec.Rollout.DeepCopy(),
+				RemediationStrategy:   src.Spec.Template.Spec.RemediationStrategy.DeepCopy(),
 			},
 		},
 	}
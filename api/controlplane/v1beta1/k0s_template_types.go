@@ -60,6 +60,15 @@ type K0sControlPlaneTemplateResourceSpec struct {
 	//+kubebuilder:validation:Enum=InPlace;Recreate;RecreateDeleteFirst
 	//+kubebuilder:default=InPlace
 	UpdateStrategy cpv2.UpdateStrategy `json:"updateStrategy,omitempty"`
+	// MachineNamingStrategy allows changing the naming pattern used when creating Machines.
+	// +kubebuilder:validation:Optional
+	MachineNamingStrategy *cpv2.MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+	// Rollout allows configuring how control plane Machines are replaced.
+	// +kubebuilder:validation:Optional
+	Rollout cpv2.K0sControlPlaneRolloutSpec `json:"rollout,omitempty,omitzero"`
+	// RemediationStrategy defines how unhealthy control plane Machines are remediated.
+	// +kubebuilder:validation:Optional
+	RemediationStrategy *cpv2.RemediationStrategy `json:"remediationStrategy,omitempty"`
 }
 
 // K0sControlPlaneTemplateMachineTemplate defines the template for Machines
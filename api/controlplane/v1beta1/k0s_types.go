@@ -22,6 +22,7 @@ import (
 	bootstrapv1 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta1"
 	bootstrapv2 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
 	cpv2 "github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
+	kmapi "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
@@ -102,6 +103,15 @@ type K0sControlPlaneSpec struct {
 	// Note: This metadata will have precedence over default labels/annotations on the Secret.
 	// +kubebuilder:validation:Optional
 	KubeconfigSecretMetadata bootstrapv2.SecretMetadata `json:"kubeconfigSecretMetadata,omitempty,omitzero"`
+	// MachineNamingStrategy allows changing the naming pattern used when creating Machines.
+	// +kubebuilder:validation:Optional
+	MachineNamingStrategy *cpv2.MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+	// Rollout allows configuring how control plane Machines are replaced.
+	// +kubebuilder:validation:Optional
+	Rollout cpv2.K0sControlPlaneRolloutSpec `json:"rollout,omitempty,omitzero"`
+	// RemediationStrategy defines how unhealthy control plane Machines are remediated.
+	// +kubebuilder:validation:Optional
+	RemediationStrategy *cpv2.RemediationStrategy `json:"remediationStrategy,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -170,6 +180,14 @@ type K0sControlPlaneStatus struct {
 	// +optional
 	UpdatedReplicas int32 `json:"updatedReplicas"`
 
+	// lastRemediation stores info about the last remediation performed.
+	// +optional
+	LastRemediation *cpv2.LastRemediationStatus `json:"lastRemediation,omitempty"`
+
+	// certificates lists the certificates generated for the cluster together with their expiry dates.
+	// +optional
+	Certificates []kmapi.CertificateStatus `json:"certificates,omitempty"`
+
 	// Conditions defines current service state of the K0sControlPlane.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
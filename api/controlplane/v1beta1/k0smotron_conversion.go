@@ -17,10 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
-	"encoding/json"
 	"fmt"
 
 	kmcv1beta1 "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta1"
+	kmapi "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
 	"github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
@@ -32,20 +32,8 @@ func (k *K0smotronControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta2.K0smotronControlPlane)
 	dst.ObjectMeta = *k.ObjectMeta.DeepCopy()
 
-	v2Spec, nonSupportedFields := kmcv1beta1.ClusterSpecToV2(k.Spec)
-	dst.Spec = v2Spec
-	if len(nonSupportedFields) > 0 {
-		if dst.Annotations == nil {
-			dst.Annotations = make(map[string]string)
-		}
-		for key, value := range nonSupportedFields {
-			encoded, err := json.Marshal(value)
-			if err != nil {
-				return fmt.Errorf("error encoding annotation %s: %w", key, err)
-			}
-			dst.Annotations[key] = string(encoded)
-		}
-	}
+	dst.Spec = kmcv1beta1.ClusterSpecToV2(k.Spec)
+	kmapi.MigrateServiceAnnotations(dst.Annotations, &dst.Spec.Service)
 	dst.Status = v1beta2.K0smotronControlPlaneStatus{
 		Initialization: v1beta2.Initialization{
 			ControlPlaneInitialized: &k.Status.Initialized,
@@ -66,19 +54,8 @@ func (k *K0smotronControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 func (k *K0smotronControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta2.K0smotronControlPlaneTemplate)
 	dst.ObjectMeta = *k.ObjectMeta.DeepCopy()
-	v2Spec, nonSupportedFields := kmcv1beta1.ClusterSpecToV2(k.Spec.Template.Spec)
-	if len(nonSupportedFields) > 0 {
-		if dst.Annotations == nil {
-			dst.Annotations = make(map[string]string)
-		}
-		for key, value := range nonSupportedFields {
-			encoded, err := json.Marshal(value)
-			if err != nil {
-				return fmt.Errorf("error encoding annotation %s: %w", key, err)
-			}
-			dst.Annotations[key] = string(encoded)
-		}
-	}
+	v2Spec := kmcv1beta1.ClusterSpecToV2(k.Spec.Template.Spec)
+	kmapi.MigrateServiceAnnotations(dst.Annotations, &v2Spec.Service)
 	dst.Spec = v1beta2.K0smotronControlPlaneTemplateSpec{
 		Template: v1beta2.K0smotronControlPlaneTemplateResource{
 			ObjectMeta: k.Spec.Template.ObjectMeta,
@@ -22,6 +22,7 @@ package v1beta1
 
 import (
 	"github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
+	k0smotron_iov1beta2 "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -110,6 +111,17 @@ func (in *K0sControlPlaneSpec) DeepCopyInto(out *K0sControlPlaneSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.KubeconfigSecretMetadata.DeepCopyInto(&out.KubeconfigSecretMetadata)
+	if in.MachineNamingStrategy != nil {
+		in, out := &in.MachineNamingStrategy, &out.MachineNamingStrategy
+		*out = new(v1beta2.MachineNamingStrategy)
+		**out = **in
+	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.RemediationStrategy != nil {
+		in, out := &in.RemediationStrategy, &out.RemediationStrategy
+		*out = new(v1beta2.RemediationStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneSpec.
@@ -126,6 +138,18 @@ func (in *K0sControlPlaneSpec) DeepCopy() *K0sControlPlaneSpec {
 func (in *K0sControlPlaneStatus) DeepCopyInto(out *K0sControlPlaneStatus) {
 	*out = *in
 	out.Initialization = in.Initialization
+	if in.LastRemediation != nil {
+		in, out := &in.LastRemediation, &out.LastRemediation
+		*out = new(v1beta2.LastRemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]k0smotron_iov1beta2.CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -260,6 +284,17 @@ func (in *K0sControlPlaneTemplateResourceSpec) DeepCopyInto(out *K0sControlPlane
 		*out = new(v1beta2.K0sControlPlaneTemplateMachineTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MachineNamingStrategy != nil {
+		in, out := &in.MachineNamingStrategy, &out.MachineNamingStrategy
+		*out = new(v1beta2.MachineNamingStrategy)
+		**out = **in
+	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.RemediationStrategy != nil {
+		in, out := &in.RemediationStrategy, &out.RemediationStrategy
+		*out = new(v1beta2.RemediationStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneTemplateResourceSpec.
@@ -18,6 +18,8 @@ package v1beta2
 
 import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	kmapi "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 )
 
 const (
@@ -42,4 +44,14 @@ const (
 
 	// K0sControlPlaneNotScalingDownReason surfaces when actual replicas <= desired replicas.
 	K0sControlPlaneNotScalingDownReason = clusterv1.NotScalingDownReason
+
+	// K0sControlPlaneCertificatesExpiringSoonCondition is true if any of the certificates generated for the cluster expires
+	// within the warning window.
+	K0sControlPlaneCertificatesExpiringSoonCondition = kmapi.ClusterCertificatesExpiringSoonCondition
+
+	// K0sControlPlaneCertificatesExpiringSoonReason surfaces when at least one certificate expires within the warning window.
+	K0sControlPlaneCertificatesExpiringSoonReason = kmapi.CertificatesExpiringSoonReason
+
+	// K0sControlPlaneCertificatesNotExpiringSoonReason surfaces when no certificate expires within the warning window.
+	K0sControlPlaneCertificatesNotExpiringSoonReason = kmapi.CertificatesNotExpiringSoonReason
 )
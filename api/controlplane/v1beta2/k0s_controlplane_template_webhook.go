@@ -0,0 +1,72 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-controlplane-cluster-x-k8s-io-v1beta2-k0scontrolplanetemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=k0scontrolplanetemplates,verbs=create;update,versions=v1beta2,name=validate-k0scontrolplanetemplate-v1beta2.k0smotron.io,admissionReviewVersions=v1
+
+// K0sControlPlaneTemplateValidator struct is responsible for validating the K0sControlPlaneTemplate resource when it is
+// created or updated, so an invalid template is rejected before a K0sControlPlane is created from it.
+type K0sControlPlaneTemplateValidator struct{}
+
+var _ webhook.CustomValidator = &K0sControlPlaneTemplateValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type K0sControlPlaneTemplate.
+func (v *K0sControlPlaneTemplateValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kcpt, ok := obj.(*K0sControlPlaneTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected a K0sControlPlaneTemplate object but got %T", obj)
+	}
+
+	return nil, validateK0sControlPlaneTemplate(kcpt)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type K0sControlPlaneTemplate.
+func (v *K0sControlPlaneTemplateValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	kcpt, ok := newObj.(*K0sControlPlaneTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected a new K0sControlPlaneTemplate object but got %T", newObj)
+	}
+
+	return nil, validateK0sControlPlaneTemplate(kcpt)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type K0sControlPlaneTemplate.
+func (v *K0sControlPlaneTemplateValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateK0sControlPlaneTemplate(kcpt *K0sControlPlaneTemplate) error {
+	return validateMachineNamingStrategy(kcpt.Spec.Template.Spec.MachineNamingStrategy)
+}
+
+// SetupK0sControlPlaneTemplateWebhookWithManager registers the webhook for K0sControlPlaneTemplate in the manager.
+func SetupK0sControlPlaneTemplateWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&K0sControlPlaneTemplate{}).
+		WithValidator(&K0sControlPlaneTemplateValidator{}).
+		Complete()
+}
@@ -24,8 +24,10 @@ import (
 
 	bootstrapv1 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
 	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+	"github.com/k0sproject/k0smotron/v2/internal/util"
 	"github.com/k0sproject/version"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -121,11 +123,48 @@ func validateK0sControlPlane(kcp *K0sControlPlane) error {
 		return err
 	}
 
-	// nolint:revive
 	if err := denyRecreateOnSingleClusters(kcp); err != nil {
 		return err
 	}
 
+	if err := validateMachineNamingStrategy(kcp.Spec.MachineNamingStrategy); err != nil {
+		return err
+	}
+
+	// nolint:revive
+	if err := denyMaxSurgeZeroOnSmallClusters(kcp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func denyMaxSurgeZeroOnSmallClusters(kcp *K0sControlPlane) error {
+	maxSurge := kcp.Spec.Rollout.Strategy.RollingUpdate.MaxSurge
+	if maxSurge != nil && *maxSurge == 0 && kcp.Spec.Replicas < 3 {
+		return fmt.Errorf("rollout.strategy.rollingUpdate.maxSurge 0 requires at least 3 replicas, got %d", kcp.Spec.Replicas)
+	}
+
+	return nil
+}
+
+func validateMachineNamingStrategy(strategy *MachineNamingStrategy) error {
+	if strategy == nil || strategy.Template == "" {
+		return nil
+	}
+
+	if !strings.Contains(strategy.Template, "{{ .random }}") {
+		return fmt.Errorf("invalid machineNamingStrategy.template %q: it must contain {{ .random }}", strategy.Template)
+	}
+
+	name, err := util.ControlPlaneMachineName(strategy.Template, "cluster", "k0scontrolplane")
+	if err != nil {
+		return fmt.Errorf("invalid machineNamingStrategy.template: %w", err)
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid machineNamingStrategy.template %q: generated name %q is not a valid name: %s", strategy.Template, name, strings.Join(errs, ", "))
+	}
+
 	return nil
 }
 
@@ -0,0 +1,102 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMachineNamingStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy *MachineNamingStrategy
+		wantErr  bool
+	}{
+		{
+			name: "no strategy",
+		},
+		{
+			name:     "valid template",
+			strategy: &MachineNamingStrategy{Template: "{{ .cluster.name }}-cp-{{ .random }}"},
+		},
+		{
+			name:     "long template is trimmed",
+			strategy: &MachineNamingStrategy{Template: "{{ .k0sControlPlane.name }}-with-a-suffix-that-is-way-too-long-for-a-name-{{ .random }}"},
+		},
+		{
+			name:     "missing random",
+			strategy: &MachineNamingStrategy{Template: "{{ .cluster.name }}-cp"},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown variable",
+			strategy: &MachineNamingStrategy{Template: "{{ .kubeadmControlPlane.name }}-{{ .random }}"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid template",
+			strategy: &MachineNamingStrategy{Template: "{{ .cluster.name -{{ .random }}"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid characters",
+			strategy: &MachineNamingStrategy{Template: "{{ .cluster.name }}_CP_{{ .random }}"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMachineNamingStrategy(tt.strategy)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestK0sControlPlaneTemplateValidator(t *testing.T) {
+	v := &K0sControlPlaneTemplateValidator{}
+	kcpt := &K0sControlPlaneTemplate{}
+	kcpt.Spec.Template.Spec.MachineNamingStrategy = &MachineNamingStrategy{Template: "{{ .cluster.name }}-cp-{{ .random }}"}
+	_, err := v.ValidateCreate(context.Background(), kcpt)
+	require.NoError(t, err)
+
+	invalid := kcpt.DeepCopy()
+	invalid.Spec.Template.Spec.MachineNamingStrategy.Template = "{{ .cluster.name }}-cp"
+	_, err = v.ValidateCreate(context.Background(), invalid)
+	require.Error(t, err)
+	_, err = v.ValidateUpdate(context.Background(), kcpt, invalid)
+	require.Error(t, err)
+}
+
+func TestDenyMaxSurgeZeroOnSmallClusters(t *testing.T) {
+	kcp := &K0sControlPlane{Spec: K0sControlPlaneSpec{Replicas: 1}}
+	require.NoError(t, denyMaxSurgeZeroOnSmallClusters(kcp))
+
+	kcp.Spec.Rollout.Strategy.RollingUpdate.MaxSurge = new(int32(0))
+	require.Error(t, denyMaxSurgeZeroOnSmallClusters(kcp))
+
+	kcp.Spec.Replicas = 3
+	require.NoError(t, denyMaxSurgeZeroOnSmallClusters(kcp))
+}
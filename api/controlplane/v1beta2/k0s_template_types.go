@@ -62,6 +62,15 @@ type K0sControlPlaneTemplateResourceSpec struct {
 	//+kubebuilder:validation:Enum=InPlace;Recreate;RecreateDeleteFirst
 	//+kubebuilder:default=InPlace
 	UpdateStrategy UpdateStrategy `json:"updateStrategy,omitempty"`
+	// MachineNamingStrategy allows changing the naming pattern used when creating Machines.
+	// +kubebuilder:validation:Optional
+	MachineNamingStrategy *MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+	// Rollout allows configuring how control plane Machines are replaced.
+	// +kubebuilder:validation:Optional
+	Rollout K0sControlPlaneRolloutSpec `json:"rollout,omitempty,omitzero"`
+	// RemediationStrategy defines how unhealthy control plane Machines are remediated.
+	// +kubebuilder:validation:Optional
+	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
 }
 
 // K0sControlPlaneTemplateMachineTemplate defines the template for Machines
@@ -16,8 +16,10 @@ package v1beta2
 
 import (
 	"slices"
+	"time"
 
 	bootstrapv2 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
+	kmapi "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -52,6 +54,14 @@ const (
 	// and recreating its replacement.
 	RemediationInProgressAnnotation = "controlplane.cluster.x-k8s.io/remediation-in-progress"
 
+	// RemediationForAnnotation is set on the Machine created as a replacement for an unhealthy Machine. It tracks
+	// the remediation history so that K0sControlPlane can enforce the RemediationStrategy retry limits.
+	RemediationForAnnotation = "controlplane.cluster.x-k8s.io/remediation-for"
+
+	// DefaultMinHealthyPeriod is the default minimum time a Machine created as a replacement must stay healthy
+	// before a new failure is considered unrelated to the previous remediation.
+	DefaultMinHealthyPeriod = time.Hour
+
 	// ControlPlanePausedCondition documents the reconciliation of the control plane is paused.
 	ControlPlanePausedCondition clusterv1.ConditionType = "Paused"
 
@@ -130,6 +140,99 @@ type K0sControlPlaneSpec struct {
 	// Note: This metadata will have precedence over default labels/annotations on the Secret.
 	// +kubebuilder:validation:Optional
 	KubeconfigSecretMetadata bootstrapv2.SecretMetadata `json:"kubeconfigSecretMetadata,omitempty,omitzero"`
+	// MachineNamingStrategy allows changing the naming pattern used when creating Machines.
+	// K0sControllerConfigs and infrastructure machines use the same name as the corresponding Machines.
+	// +kubebuilder:validation:Optional
+	MachineNamingStrategy *MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+	// Rollout allows configuring how control plane Machines are replaced.
+	// +kubebuilder:validation:Optional
+	Rollout K0sControlPlaneRolloutSpec `json:"rollout,omitempty,omitzero"`
+	// RemediationStrategy defines how unhealthy control plane Machines are remediated.
+	// +kubebuilder:validation:Optional
+	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
+}
+
+// RemediationStrategy allows controlling how remediation of unhealthy control plane Machines is retried.
+type RemediationStrategy struct {
+	// MaxRetry is the maximum number of retries while attempting to remediate an unhealthy Machine.
+	// A retry happens when a Machine that was created as a replacement for an unhealthy Machine also fails.
+	// If not set, remediation is retried infinitely.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRetry *int32 `json:"maxRetry,omitempty"`
+	// RetryPeriod is the duration K0sControlPlane waits before remediating a Machine that was created as a
+	// replacement for an unhealthy Machine. If not set, a retry happens immediately.
+	// +kubebuilder:validation:Optional
+	RetryPeriod metav1.Duration `json:"retryPeriod,omitempty"`
+	// MinHealthyPeriod defines the duration after which K0sControlPlane considers any failure of a Machine
+	// unrelated to the previous remediation, so the retry count starts over. Default: 1h.
+	// +kubebuilder:validation:Optional
+	MinHealthyPeriod *metav1.Duration `json:"minHealthyPeriod,omitempty"`
+}
+
+// LastRemediationStatus describes the last remediation performed by the K0sControlPlane.
+type LastRemediationStatus struct {
+	// Machine is the name of the Machine that was remediated.
+	Machine string `json:"machine"`
+	// Time is the time the remediation started.
+	Time metav1.Time `json:"time"`
+	// RetryCount is the number of times the remediation was retried for the same unhealthy Machine.
+	RetryCount int32 `json:"retryCount"`
+}
+
+// K0sControlPlaneRolloutSpec defines how control plane Machines are replaced.
+type K0sControlPlaneRolloutSpec struct {
+	// RolloutAfter forces a rollout of every control plane Machine created before this time, even if the
+	// K0sControlPlane spec has not changed. This is useful to pick up changes that are not part of the
+	// K0sControlPlane, e.g. a refreshed OS image in the infrastructure template.
+	// +kubebuilder:validation:Optional
+	RolloutAfter *metav1.Time `json:"rolloutAfter,omitempty"`
+	// Strategy defines the rollout strategy.
+	// +kubebuilder:validation:Optional
+	Strategy K0sControlPlaneRolloutStrategy `json:"strategy,omitempty,omitzero"`
+}
+
+// K0sControlPlaneRolloutStrategy defines the rollout strategy for control plane Machines.
+type K0sControlPlaneRolloutStrategy struct {
+	// RollingUpdate defines the rolling update parameters.
+	// +kubebuilder:validation:Optional
+	RollingUpdate K0sControlPlaneRollingUpdate `json:"rollingUpdate,omitempty,omitzero"`
+}
+
+// K0sControlPlaneRollingUpdate defines the parameters of a rolling update.
+type K0sControlPlaneRollingUpdate struct {
+	// MaxSurge is the number of Machines that can be created above the desired number of replicas while
+	// replacing Machines. With 1 a new Machine is created before an old one is deleted, with 0 an old Machine
+	// is deleted first. 0 requires at least 3 replicas, so that etcd keeps its quorum.
+	// If not set, it is derived from the UpdateStrategy.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	MaxSurge *int32 `json:"maxSurge,omitempty"`
+}
+
+// IsRolloutDue returns true if the Machine was created before spec.rollout.rolloutAfter and that time has passed.
+func (k *K0sControlPlane) IsRolloutDue(machine *clusterv1.Machine, now time.Time) bool {
+	rolloutAfter := k.Spec.Rollout.RolloutAfter
+	if rolloutAfter == nil || rolloutAfter.After(now) {
+		return false
+	}
+
+	return machine.CreationTimestamp.Before(rolloutAfter)
+}
+
+// MachineNamingStrategy allows changing the naming pattern used when creating Machines.
+type MachineNamingStrategy struct {
+	// Template defines the template to use for generating the names of the Machine objects.
+	// If not defined, it will fall back to `{{ .k0sControlPlane.name }}-{{ .random }}`.
+	// If the generated name exceeds 63 characters, it is trimmed to 58 characters and gets a random
+	// suffix of 5 characters.
+	// The template allows the variables `.cluster.name`, `.k0sControlPlane.name` and `.random`.
+	// `.random` is substituted with a random alphanumeric string of 5 characters and must be part of the template.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Template string `json:"template,omitempty"`
 }
 
 // K0sControlPlaneMachineTemplate describes the data needed to create a Machine from a template.
@@ -194,6 +297,14 @@ type K0sControlPlaneStatus struct {
 	// +optional
 	UpToDateReplicas *int32 `json:"upToDateReplicas,omitempty"`
 
+	// lastRemediation stores info about the last remediation performed.
+	// +optional
+	LastRemediation *LastRemediationStatus `json:"lastRemediation,omitempty"`
+
+	// certificates lists the certificates generated for the cluster together with their expiry dates.
+	// +optional
+	Certificates []kmapi.CertificateStatus `json:"certificates,omitempty"`
+
 	// Conditions defines current service state of the K0sControlPlane.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	k0smotron_iov1beta2 "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -136,6 +137,62 @@ func (in *K0sControlPlaneMachineTemplate) DeepCopy() *K0sControlPlaneMachineTemp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K0sControlPlaneRollingUpdate) DeepCopyInto(out *K0sControlPlaneRollingUpdate) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneRollingUpdate.
+func (in *K0sControlPlaneRollingUpdate) DeepCopy() *K0sControlPlaneRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(K0sControlPlaneRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K0sControlPlaneRolloutSpec) DeepCopyInto(out *K0sControlPlaneRolloutSpec) {
+	*out = *in
+	if in.RolloutAfter != nil {
+		in, out := &in.RolloutAfter, &out.RolloutAfter
+		*out = (*in).DeepCopy()
+	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneRolloutSpec.
+func (in *K0sControlPlaneRolloutSpec) DeepCopy() *K0sControlPlaneRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(K0sControlPlaneRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K0sControlPlaneRolloutStrategy) DeepCopyInto(out *K0sControlPlaneRolloutStrategy) {
+	*out = *in
+	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneRolloutStrategy.
+func (in *K0sControlPlaneRolloutStrategy) DeepCopy() *K0sControlPlaneRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(K0sControlPlaneRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K0sControlPlaneSpec) DeepCopyInto(out *K0sControlPlaneSpec) {
 	*out = *in
@@ -146,6 +203,17 @@ func (in *K0sControlPlaneSpec) DeepCopyInto(out *K0sControlPlaneSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.KubeconfigSecretMetadata.DeepCopyInto(&out.KubeconfigSecretMetadata)
+	if in.MachineNamingStrategy != nil {
+		in, out := &in.MachineNamingStrategy, &out.MachineNamingStrategy
+		*out = new(MachineNamingStrategy)
+		**out = **in
+	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.RemediationStrategy != nil {
+		in, out := &in.RemediationStrategy, &out.RemediationStrategy
+		*out = new(RemediationStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneSpec.
@@ -187,6 +255,18 @@ func (in *K0sControlPlaneStatus) DeepCopyInto(out *K0sControlPlaneStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.LastRemediation != nil {
+		in, out := &in.LastRemediation, &out.LastRemediation
+		*out = new(LastRemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]k0smotron_iov1beta2.CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -321,6 +401,17 @@ func (in *K0sControlPlaneTemplateResourceSpec) DeepCopyInto(out *K0sControlPlane
 		*out = new(K0sControlPlaneTemplateMachineTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MachineNamingStrategy != nil {
+		in, out := &in.MachineNamingStrategy, &out.MachineNamingStrategy
+		*out = new(MachineNamingStrategy)
+		**out = **in
+	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.RemediationStrategy != nil {
+		in, out := &in.RemediationStrategy, &out.RemediationStrategy
+		*out = new(RemediationStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneTemplateResourceSpec.
@@ -349,6 +440,21 @@ func (in *K0sControlPlaneTemplateSpec) DeepCopy() *K0sControlPlaneTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K0sControlPlaneTemplateValidator) DeepCopyInto(out *K0sControlPlaneTemplateValidator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K0sControlPlaneTemplateValidator.
+func (in *K0sControlPlaneTemplateValidator) DeepCopy() *K0sControlPlaneTemplateValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(K0sControlPlaneTemplateValidator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K0sControlPlaneValidator) DeepCopyInto(out *K0sControlPlaneValidator) {
 	*out = *in
@@ -577,3 +683,60 @@ func (in *K0smotronControlPlaneValidator) DeepCopy() *K0smotronControlPlaneValid
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastRemediationStatus) DeepCopyInto(out *LastRemediationStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastRemediationStatus.
+func (in *LastRemediationStatus) DeepCopy() *LastRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LastRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineNamingStrategy) DeepCopyInto(out *MachineNamingStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineNamingStrategy.
+func (in *MachineNamingStrategy) DeepCopy() *MachineNamingStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineNamingStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStrategy) DeepCopyInto(out *RemediationStrategy) {
+	*out = *in
+	if in.MaxRetry != nil {
+		in, out := &in.MaxRetry, &out.MaxRetry
+		*out = new(int32)
+		**out = **in
+	}
+	out.RetryPeriod = in.RetryPeriod
+	if in.MinHealthyPeriod != nil {
+		in, out := &in.MinHealthyPeriod, &out.MinHealthyPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStrategy.
+func (in *RemediationStrategy) DeepCopy() *RemediationStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -53,8 +53,16 @@ type RemoteMachineTemplateResource struct {
 // RemoteMachineTemplateResourceSpec defines the desired state of RemoteMachineTemplateResource
 type RemoteMachineTemplateResourceSpec struct {
 	Pool string `json:"pool"`
+	// PoolSelector selects the machines of the pool that may be reserved by their labels. All the machines of the pool
+	// may be reserved if not set.
+	// +kubebuilder:validation:Optional
+	PoolSelector *metav1.LabelSelector `json:"poolSelector,omitempty"`
 	// ProvisionJob describes the kubernetes Job to use to provision the machine.
 	ProvisionJob *ProvisionJob `json:"provisionJob,omitempty"`
+	// Bastion is the jump host used to connect to the remote machines over SSH. It is used for the machines of the pool
+	// that do not define their own bastion.
+	// +kubebuilder:validation:Optional
+	Bastion *Bastion `json:"bastion,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -36,14 +36,93 @@ const (
 	RemoteMachineReadyCondition = "Ready"
 	// RemoteMachineReadyReason is the reason used when the RemoteMachine is ready after the bootstrap commands were executed successfully on the remote machine.
 	RemoteMachineReadyReason = "Ready"
+	// RemoteMachineHostKeyVerifiedCondition is the condition type that indicates whether the SSH host key of the remote machine has been verified.
+	RemoteMachineHostKeyVerifiedCondition = "HostKeyVerified"
+	// RemoteMachineHostKeyVerifiedReason is the reason used when the SSH host key of the remote machine matches the trusted keys.
+	RemoteMachineHostKeyVerifiedReason = "HostKeyVerified"
+	// RemoteMachineHostKeyMismatchReason is the reason used when the SSH host key of the remote machine does not match the trusted keys.
+	RemoteMachineHostKeyMismatchReason = "HostKeyMismatch"
+	// RemoteMachineHostKeyVerificationDisabledReason is the reason used when the host key policy is Insecure.
+	RemoteMachineHostKeyVerificationDisabledReason = "VerificationDisabled"
+	// RemoteMachineCleanupSucceededCondition is the condition type that indicates whether the last cleanup of the remote machine succeeded.
+	RemoteMachineCleanupSucceededCondition = "CleanupSucceeded"
+	// RemoteMachineCleanupSucceededReason is the reason used when the remote machine has been cleaned up.
+	RemoteMachineCleanupSucceededReason = "CleanupSucceeded"
+	// RemoteMachineCleanupFailedReason is the reason used when the cleanup of the remote machine failed.
+	RemoteMachineCleanupFailedReason = "CleanupFailed"
+	// RemoteMachineReprovisioningReason is the reason used while the remote machine is reprovisioned.
+	RemoteMachineReprovisioningReason = "Reprovisioning"
 	// InternalErrorReason indicates that an internal error occurred during the provisioning process.
 	InternalErrorReason = "InternalError"
 )
 
+// ReprovisionAnnotation requests the reprovisioning of a RemoteMachine when set on it: the machine is cleaned up, and
+// bootstrapped again with regenerated bootstrap data. The annotation is removed once the machine has been cleaned up.
+const ReprovisionAnnotation = "k0smotron.io/reprovision"
+
+// HostKeyPolicy defines how the SSH host key of a remote machine is verified.
+// +kubebuilder:validation:Enum=Strict;TrustOnFirstUse;Insecure
+type HostKeyPolicy string
+
+const (
+	// HostKeyPolicyStrict only accepts the host keys listed in the hostKeyRef.
+	HostKeyPolicyStrict HostKeyPolicy = "Strict"
+	// HostKeyPolicyTrustOnFirstUse accepts the host key seen on the first connection, or a key listed in the hostKeyRef,
+	// and only accepts that key afterwards.
+	HostKeyPolicyTrustOnFirstUse HostKeyPolicy = "TrustOnFirstUse"
+	// HostKeyPolicyInsecure accepts any host key.
+	HostKeyPolicyInsecure HostKeyPolicy = "Insecure"
+)
+
+// PoolQuarantineReason is the reason why a pooled machine is quarantined.
+// +kubebuilder:validation:Enum=CleanupFailed;Unreachable;VerificationFailed;DiskNotClean
+type PoolQuarantineReason string
+
+const (
+	// PoolQuarantineCleanupFailed is the reason used when the cleanup of the machine failed when it was returned to the pool.
+	PoolQuarantineCleanupFailed PoolQuarantineReason = "CleanupFailed"
+	// PoolQuarantineUnreachable is the reason used when the machine could not be connected to.
+	PoolQuarantineUnreachable PoolQuarantineReason = "Unreachable"
+	// PoolQuarantineVerificationFailed is the reason used when the verification command of the health check failed.
+	PoolQuarantineVerificationFailed PoolQuarantineReason = "VerificationFailed"
+	// PoolQuarantineDiskNotClean is the reason used when files of a previous installation are left on the machine.
+	PoolQuarantineDiskNotClean PoolQuarantineReason = "DiskNotClean"
+)
+
+// Transport is the protocol used to connect to a remote machine.
+// +kubebuilder:validation:Enum=ssh;winrm
+type Transport string
+
+const (
+	// TransportSSH connects to the remote machine over SSH.
+	TransportSSH Transport = "ssh"
+	// TransportWinRM connects to a Windows remote machine over WinRM.
+	TransportWinRM Transport = "winrm"
+)
+
+// BootstrapStepPhase is the phase of a bootstrap step.
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type BootstrapStepPhase string
+
+const (
+	// BootstrapStepPending is the phase of a step which has not started yet.
+	BootstrapStepPending BootstrapStepPhase = "Pending"
+	// BootstrapStepRunning is the phase of a step which is running.
+	BootstrapStepRunning BootstrapStepPhase = "Running"
+	// BootstrapStepSucceeded is the phase of a step which has finished successfully.
+	BootstrapStepSucceeded BootstrapStepPhase = "Succeeded"
+	// BootstrapStepFailed is the phase of a step which has failed.
+	BootstrapStepFailed BootstrapStepPhase = "Failed"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:metadata:labels="cluster.x-k8s.io/v1beta2=v1beta2"
 // +kubebuilder:metadata:labels="cluster.x-k8s.io/provider=infrastructure-k0smotron"
+// +kubebuilder:printcolumn:name="Address",type=string,JSONPath=".spec.address",description="IP address or DNS name of the remote machine"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Indicates if the machine is ready"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp",description="Time duration since creation of the RemoteMachine"
+// +kubebuilder:printcolumn:name="Step",type=string,JSONPath=".status.currentStep",description="Current bootstrap step of the remote machine",priority=1
 // +kubebuilder:storageversion
 
 // RemoteMachine is the Schema for the remotemachines API
@@ -61,6 +140,16 @@ type RemoteMachineSpec struct {
 	// +kubebuilder:validation:Optional
 	Pool string `json:"pool,omitempty"`
 
+	// PoolSelector selects the machines of the pool that may be reserved by their labels. All the machines of the pool
+	// may be reserved if not set.
+	// +kubebuilder:validation:Optional
+	PoolSelector *metav1.LabelSelector `json:"poolSelector,omitempty"`
+
+	// FailureDomain is the failure domain of the machine. It is set to the failure domain of the reserved pooled
+	// machine.
+	// +kubebuilder:validation:Optional
+	FailureDomain string `json:"failureDomain,omitempty"`
+
 	// ProviderID is the ID of the machine in the provider.
 	// +kubebuilder:validation:Optional
 	ProviderID string `json:"providerID,omitempty"`
@@ -89,6 +178,17 @@ type RemoteMachineSpec struct {
 	// +kubebuilder:default=false
 	CommandsAsScript bool `json:"commandsAsScript,omitempty"`
 
+	// CommandTimeout is the maximum time a bootstrap command may run before it is aborted. Commands are not limited
+	// in time if not set.
+	// +kubebuilder:validation:Optional
+	CommandTimeout *metav1.Duration `json:"commandTimeout,omitempty"`
+
+	// CommandRetries is the number of times a failed bootstrap command or file upload is retried before the bootstrap
+	// fails.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	CommandRetries int32 `json:"commandRetries,omitempty"`
+
 	// WorkingDir is the directory to use as working directory when connecting to the remote machine.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="/etc/k0smotron"
@@ -99,6 +199,41 @@ type RemoteMachineSpec struct {
 	// +kubebuilder:validation:Optional
 	SSHKeyRef SecretRef `json:"sshKeyRef,omitempty"`
 
+	// HostKeyRef is a reference to a Secret or a ConfigMap that contains the known_hosts lines of the remote machine and
+	// of its bastion, if any.
+	// +kubebuilder:validation:Optional
+	HostKeyRef *HostKeyRef `json:"hostKeyRef,omitempty"`
+
+	// HostKeyPolicy defines how the SSH host key of the remote machine is verified. Strict only accepts the keys listed
+	// in hostKeyRef. TrustOnFirstUse accepts a key listed in hostKeyRef, or any key if hostKeyRef is not set, and records
+	// it in status.hostKey, only that key is accepted afterwards. Insecure disables the verification.
+	// The policy also applies to the bastion, if any. Defaults to Strict if hostKeyRef is set, and to TrustOnFirstUse
+	// otherwise.
+	// +kubebuilder:validation:Optional
+	HostKeyPolicy HostKeyPolicy `json:"hostKeyPolicy,omitempty"`
+
+	// Bastion is the jump host used to connect to the remote machine over SSH. If not set, the machine is connected
+	// directly.
+	// +kubebuilder:validation:Optional
+	Bastion *Bastion `json:"bastion,omitempty"`
+
+	// Transport is the protocol used to connect to the remote machine. With winrm, the bootstrap of a Windows machine
+	// is run over WinRM, using the connection defined in the winrm field.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ssh
+	Transport Transport `json:"transport,omitempty"`
+
+	// WinRM defines the WinRM connection to the remote machine, used with the winrm transport.
+	// +kubebuilder:validation:Optional
+	WinRM *WinRM `json:"winrm,omitempty"`
+
+	// PowerManagement defines how the power of the machine is managed through its baseboard management controller.
+	// The machine is powered on before it is bootstrapped, power cycled when its unhealthy Machine waits for external
+	// remediation, as requested by a MachineHealthCheck with a remediation template, and powered off once it has been
+	// cleaned up and returned to its pool.
+	// +kubebuilder:validation:Optional
+	PowerManagement *PowerManagement `json:"powerManagement,omitempty"`
+
 	// CleanUpCommands allows the user to run custom commands during the machine cleanup process.
 	// If CleanUpCommands is set and k0s is used as the bootstrap provider,
 	// the user is responsible for the complete cleanup of the k0s installation.
@@ -106,8 +241,18 @@ type RemoteMachineSpec struct {
 	// +kubebuilder:validation:Optional
 	CleanUpCommands []string `json:"cleanUpCommands,omitempty"`
 
+	// Cleanup customizes the cleanup of the k0s installation of the machine. It is ignored if CleanUpCommands is set.
+	// +kubebuilder:validation:Optional
+	Cleanup *Cleanup `json:"cleanup,omitempty"`
+
 	// ProvisionJob describes the kubernetes Job to use to provision the machine.
 	ProvisionJob *ProvisionJob `json:"provisionJob,omitempty"`
+
+	// ReprovisionGeneration requests the reprovisioning of the machine when increased: the machine is cleaned up, and
+	// bootstrapped again with regenerated bootstrap data.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ReprovisionGeneration int64 `json:"reprovisionGeneration,omitempty"`
 }
 
 // ProvisionJob describes the kubernetes Job to use to provision the machine.
@@ -134,11 +279,56 @@ type RemoteMachineStatus struct {
 	// conditions contains the conditions of the RemoteMachine, which represent the current state of the machine.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// hostKey is the SSH host key of the machine accepted on the first connection with the TrustOnFirstUse host key
+	// policy, in the authorized_keys format.
+	// +optional
+	HostKey string `json:"hostKey,omitempty"`
+	// bastionHostKey is the SSH host key of the bastion accepted on the first connection with the TrustOnFirstUse host
+	// key policy, in the authorized_keys format.
+	// +optional
+	BastionHostKey string `json:"bastionHostKey,omitempty"`
+	// currentStep is the name of the bootstrap step running, or of the last step run once the bootstrap has finished.
+	// +optional
+	CurrentStep string `json:"currentStep,omitempty"`
+	// bootstrapSteps lists the steps of the last bootstrap of the machine, with their outcome.
+	// +optional
+	// +listType=atomic
+	BootstrapSteps []BootstrapStep `json:"bootstrapSteps,omitempty"`
+	// lastPowerCycleTime is the time the machine was last power cycled because its unhealthy Machine waited for external remediation.
+	// +optional
+	LastPowerCycleTime *metav1.Time `json:"lastPowerCycleTime,omitempty"`
+	// observedReprovisionGeneration is the reprovisionGeneration the machine was last reprovisioned for.
+	// +optional
+	ObservedReprovisionGeneration int64 `json:"observedReprovisionGeneration,omitempty"`
+	// reprovisioning is true while the machine is reprovisioned, from its cleanup until it is bootstrapped again.
+	// +optional
+	Reprovisioning bool `json:"reprovisioning,omitempty"`
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *RemoteMachineStatusDeprecated `json:"deprecated,omitempty"`
 }
 
+// GetHostKeyPolicy returns the host key policy of the machine, defaulting to Strict if a hostKeyRef is set and to
+// TrustOnFirstUse otherwise.
+func (rms *RemoteMachineSpec) GetHostKeyPolicy() HostKeyPolicy {
+	if rms.HostKeyPolicy != "" {
+		return rms.HostKeyPolicy
+	}
+	if rms.HostKeyRef != nil {
+		return HostKeyPolicyStrict
+	}
+	return HostKeyPolicyTrustOnFirstUse
+}
+
+// ReprovisionRequested returns true if the reprovisioning of the machine is requested, with the ReprovisionAnnotation
+// or by increasing the reprovisionGeneration.
+func (rm *RemoteMachine) ReprovisionRequested() bool {
+	if _, ok := rm.Annotations[ReprovisionAnnotation]; ok {
+		return true
+	}
+	return rm.Spec.ReprovisionGeneration > rm.Status.ObservedReprovisionGeneration
+}
+
 // RemoteMachineStatusDeprecated defines the observed state of RemoteMachine for deprecated fields, which will be removed in future versions.
 type RemoteMachineStatusDeprecated struct {
 	// v1beta1 groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
@@ -192,6 +382,29 @@ func (rm *RemoteMachine) SetConditions(conditions []metav1.Condition) {
 	rm.Status.Conditions = conditions
 }
 
+// BootstrapStep describes a step of the bootstrap of a remote machine, either a file upload or a command.
+type BootstrapStep struct {
+	// name describes the step, with the uploaded file or the command run.
+	Name string `json:"name"`
+	// phase is the phase of the step.
+	Phase BootstrapStepPhase `json:"phase"`
+	// exitCode is the exit code of the command run by the step, if it exited.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// startTime is the time the step started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// duration is the time the step took to finish.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// output is the combined stdout and stderr of the step, truncated to its last kilobyte.
+	// +optional
+	Output string `json:"output,omitempty"`
+	// message describes why the step failed, if it did not run a command.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // RemoteMachineInitializationStatus provides observations of the RemoteMachine initialization process.
 type RemoteMachineInitializationStatus struct {
 	// provisioned is true when the RemoteMachine's infrastructure is fully provisioned.
@@ -207,6 +420,137 @@ type SecretRef struct {
 	Name string `json:"name"`
 }
 
+// HostKeyRef is a reference to a Secret or a ConfigMap that contains known_hosts lines.
+type HostKeyRef struct {
+	// Kind is the kind of the referenced object.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +kubebuilder:default=Secret
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the referenced object.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Key is the key in the referenced object that contains the known_hosts lines.
+	// +kubebuilder:default=known_hosts
+	Key string `json:"key,omitempty"`
+}
+
+// Bastion defines the jump host used to connect to a remote machine over SSH.
+type Bastion struct {
+	// Address is the IP address or DNS name of the bastion host.
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// Port is the SSH port of the bastion host.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=22
+	Port int `json:"port,omitempty"`
+
+	// User is the user to use when connecting to the bastion host.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="root"
+	User string `json:"user,omitempty"`
+
+	// SSHKeyRef is a reference to a secret that contains the SSH private key for the bastion host.
+	// The key must be placed on the secret using the key "value". Defaults to the SSH key of the remote machine.
+	// +kubebuilder:validation:Optional
+	SSHKeyRef *SecretRef `json:"sshKeyRef,omitempty"`
+}
+
+// WinRM defines the WinRM connection to a Windows remote machine.
+type WinRM struct {
+	// CredentialsRef is a reference to a secret that contains the credentials of the Windows user. The password must
+	// be placed on the secret using the key "password", and the user name using the key "username", which defaults to
+	// Administrator. The CA certificate verifying the WinRM HTTPS certificate of the machine may be placed on the
+	// secret using the key "ca.crt".
+	// +kubebuilder:validation:Required
+	CredentialsRef SecretRef `json:"credentialsRef"`
+
+	// Port is the WinRM port of the remote machine. Defaults to 5986 with HTTPS, and to 5985 otherwise.
+	// +kubebuilder:validation:Optional
+	Port int `json:"port,omitempty"`
+
+	// UseHTTPS connects to the WinRM HTTPS listener of the remote machine.
+	// +kubebuilder:validation:Optional
+	UseHTTPS bool `json:"useHTTPS,omitempty"`
+
+	// Insecure disables the verification of the WinRM HTTPS certificate of the remote machine.
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// UseNTLM authenticates with NTLM instead of basic authentication.
+	// +kubebuilder:validation:Optional
+	UseNTLM bool `json:"useNTLM,omitempty"`
+}
+
+// GetPort returns the WinRM port of the remote machine, defaulting to the standard port of the listener.
+func (w *WinRM) GetPort() int {
+	if w.Port != 0 {
+		return w.Port
+	}
+	if w.UseHTTPS {
+		return 5986
+	}
+	return 5985
+}
+
+// Cleanup customizes the cleanup of the k0s installation of a remote machine.
+type Cleanup struct {
+	// PreResetCommands are run once the k0s service has been stopped, before k0s is reset.
+	// +kubebuilder:validation:Optional
+	PreResetCommands []string `json:"preResetCommands,omitempty"`
+
+	// ResetFlags are the extra flags of the k0s reset command, such as --data-dir or --cri-socket. The
+	// --kubelet-root-dir flag is set from the bootstrap commands if not listed.
+	// +kubebuilder:validation:Optional
+	ResetFlags []string `json:"resetFlags,omitempty"`
+
+	// ExtraPathsToRemove are removed once k0s has been reset, such as extra data directories or containerd state.
+	// The paths must be absolute and clean, such as /var/lib/containerd or C:\etc\cni, and not a root directory.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Pattern=`^((/([^/.][^/]*|\.[^/.][^/]*|\.\.[^/]+))+|[A-Za-z]:(\\([^\\/.][^\\/]*|\.[^\\/.][^\\/]*|\.\.[^\\/]+))+)$`
+	ExtraPathsToRemove []string `json:"extraPathsToRemove,omitempty"`
+
+	// Reboot reboots the machine once it has been cleaned up, which removes the leftovers of k0s such as its network
+	// interfaces and mounts.
+	// +kubebuilder:validation:Optional
+	Reboot bool `json:"reboot,omitempty"`
+
+	// RetryTimeout is the time a failed cleanup is retried for, since it first failed, before the RemoteMachine is
+	// deleted anyway. A failed cleanup is not retried if not set.
+	// +kubebuilder:validation:Optional
+	RetryTimeout *metav1.Duration `json:"retryTimeout,omitempty"`
+}
+
+// PowerManagement defines how the power of a remote machine is managed.
+type PowerManagement struct {
+	// Redfish defines the Redfish service of the baseboard management controller of the machine.
+	// +kubebuilder:validation:Required
+	Redfish Redfish `json:"redfish"`
+}
+
+// Redfish defines a Redfish service managing the power of a computer system.
+type Redfish struct {
+	// Address is the URL of the Redfish service, such as https://10.0.0.10.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address"`
+
+	// SystemID is the ID of the computer system of the machine in the Redfish service. Defaults to the first system
+	// of the service.
+	// +kubebuilder:validation:Optional
+	SystemID string `json:"systemID,omitempty"`
+
+	// CredentialsRef is a reference to a secret that contains the credentials of the Redfish service, with the keys
+	// "username" and "password". The CA certificate verifying the HTTPS certificate of the service may be placed on
+	// the secret using the key "ca.crt".
+	// +kubebuilder:validation:Required
+	CredentialsRef SecretRef `json:"credentialsRef"`
+
+	// Insecure disables the verification of the HTTPS certificate of the Redfish service.
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 
 // RemoteMachineList contains a list of RemoteMachine
@@ -221,8 +565,10 @@ type RemoteMachineList struct {
 // +kubebuilder:metadata:labels="cluster.x-k8s.io/v1beta2=v1beta2"
 // +kubebuilder:metadata:labels="cluster.x-k8s.io/provider=infrastructure-k0smotron"
 // +kubebuilder:printcolumn:name="Address",type=string,JSONPath=".spec.machine.address",description="IP address or DNS name of the remote machine"
+// +kubebuilder:printcolumn:name="Failure Domain",type=string,JSONPath=".spec.failureDomain",description="Failure domain of the machine"
 // +kubebuilder:printcolumn:name="Reserved",type=string,JSONPath=".status.reserved",description="Indicates if the machine is reserved"
 // +kubebuilder:printcolumn:name="Remote Machine",type=string,JSONPath=".status.machineRef.name",description="Reference to the RemoteMachine"
+// +kubebuilder:printcolumn:name="Quarantined",type=string,JSONPath=".status.quarantineReason",description="Reason why the machine is quarantined"
 // +kubebuilder:storageversion
 
 // PooledRemoteMachine represents a RemoteMachine that is part of a pool and can be reserved for use.
@@ -236,8 +582,40 @@ type PooledRemoteMachine struct {
 
 // PooledRemoteMachineSpec defines the desired state of PooledRemoteMachine
 type PooledRemoteMachineSpec struct {
-	Pool    string            `json:"pool"`
-	Machine PooledMachineSpec `json:"machine"`
+	Pool string `json:"pool"`
+	// FailureDomain is the failure domain of the machine, such as its rack or its site. The Machines placed in a
+	// failure domain only reserve the pooled machines of that failure domain.
+	// +kubebuilder:validation:Optional
+	FailureDomain string            `json:"failureDomain,omitempty"`
+	Machine       PooledMachineSpec `json:"machine"`
+	// HealthCheck defines the health probes run on the machine while it is not reserved. The machine is quarantined
+	// when a probe fails, and released once all the probes pass.
+	// +kubebuilder:validation:Optional
+	HealthCheck *PoolHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// PoolHealthCheck defines the health probes run on a free pooled machine. The machine is always probed for
+// reachability by connecting to it.
+type PoolHealthCheck struct {
+	// Interval is the time between two probes of the machine. Zero means the default, and intervals shorter than 30s
+	// are raised to 30s.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// Command is a verification command run on the machine, which must exit with status 0. It is run with the
+	// privileges of the bootstrap commands, by cmd.exe with the winrm transport.
+	// +kubebuilder:validation:Optional
+	Command string `json:"command,omitempty"`
+
+	// CleanPaths lists the paths which must not exist on the machine, to check that a previous k0s installation has
+	// been cleaned up. Defaults to the k0s data directory, /var/lib/k0s, or C:\var\lib\k0s with the winrm transport.
+	// +kubebuilder:validation:Optional
+	CleanPaths []string `json:"cleanPaths,omitempty"`
+
+	// SkipCleanCheck disables the check of the clean paths.
+	// +kubebuilder:validation:Optional
+	SkipCleanCheck bool `json:"skipCleanCheck,omitempty"`
 }
 
 // PooledMachineSpec defines the connection details and provisioning information for a machine in a pool.
@@ -264,6 +642,15 @@ type PooledMachineSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	CommandsAsScript bool `json:"commandsAsScript,omitempty"`
+	// CommandTimeout is the maximum time a bootstrap command may run before it is aborted. Commands are not limited
+	// in time if not set.
+	// +kubebuilder:validation:Optional
+	CommandTimeout *metav1.Duration `json:"commandTimeout,omitempty"`
+	// CommandRetries is the number of times a failed bootstrap command or file upload is retried before the bootstrap
+	// fails.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	CommandRetries int32 `json:"commandRetries,omitempty"`
 	// WorkingDir is the directory to use as working directory when connecting to the remote machine.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="/etc/k0smotron"
@@ -271,17 +658,92 @@ type PooledMachineSpec struct {
 	// CleanUpCommands allow the user to run custom command for the clean up process of the machine.
 	// +kubebuilder:validation:Optional
 	CleanUpCommands []string `json:"cleanUpCommands,omitempty"`
+	// Cleanup customizes the cleanup of the k0s installation of the machine. It is ignored if CleanUpCommands is set.
+	// +kubebuilder:validation:Optional
+	Cleanup *Cleanup `json:"cleanup,omitempty"`
 
 	// SSHKeyRef is a reference to a secret that contains the SSH private key.
-	// The key must be placed on the secret using the key "value".
-	// +kubebuilder:validation:Required
-	SSHKeyRef SecretRef `json:"sshKeyRef"`
+	// The key must be placed on the secret using the key "value". It is required with the ssh transport.
+	// +kubebuilder:validation:Optional
+	SSHKeyRef SecretRef `json:"sshKeyRef,omitempty"`
+
+	// HostKeyRef is a reference to a Secret or a ConfigMap that contains the known_hosts lines of the remote machine and
+	// of its bastion, if any.
+	// +kubebuilder:validation:Optional
+	HostKeyRef *HostKeyRef `json:"hostKeyRef,omitempty"`
+
+	// HostKeyPolicy defines how the SSH host key of the remote machine is verified. Strict only accepts the keys listed
+	// in hostKeyRef. TrustOnFirstUse accepts a key listed in hostKeyRef, or any key if hostKeyRef is not set, and records
+	// it in status.hostKey, only that key is accepted afterwards. Insecure disables the verification.
+	// The policy also applies to the bastion, if any. Defaults to Strict if hostKeyRef is set, and to TrustOnFirstUse
+	// otherwise.
+	// +kubebuilder:validation:Optional
+	HostKeyPolicy HostKeyPolicy `json:"hostKeyPolicy,omitempty"`
+
+	// Bastion is the jump host used to connect to the remote machine over SSH. If not set, the machine is connected
+	// directly.
+	// +kubebuilder:validation:Optional
+	Bastion *Bastion `json:"bastion,omitempty"`
+
+	// Transport is the protocol used to connect to the remote machine. With winrm, the bootstrap of a Windows machine
+	// is run over WinRM, using the connection defined in the winrm field.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ssh
+	Transport Transport `json:"transport,omitempty"`
+
+	// WinRM defines the WinRM connection to the remote machine, used with the winrm transport.
+	// +kubebuilder:validation:Optional
+	WinRM *WinRM `json:"winrm,omitempty"`
+
+	// PowerManagement defines how the power of the machine is managed through its baseboard management controller.
+	// The machine is powered on before it is bootstrapped, power cycled when its unhealthy Machine waits for external
+	// remediation, as requested by a MachineHealthCheck with a remediation template, and powered off once it has been
+	// cleaned up and returned to its pool.
+	// +kubebuilder:validation:Optional
+	PowerManagement *PowerManagement `json:"powerManagement,omitempty"`
 }
 
 // PooledRemoteMachineStatus defines the observed state of PooledRemoteMachine
 type PooledRemoteMachineStatus struct {
 	Reserved   bool             `json:"reserved"`
 	MachineRef RemoteMachineRef `json:"machineRef"`
+	// lastMachineRef is a reference to the last RemoteMachine which held the machine.
+	// +optional
+	LastMachineRef *RemoteMachineRef `json:"lastMachineRef,omitempty"`
+	// quarantined is true when the machine may not be reserved, because its cleanup or a health probe failed.
+	// +optional
+	Quarantined bool `json:"quarantined,omitempty"`
+	// quarantineReason is the reason why the machine is quarantined.
+	// +optional
+	QuarantineReason PoolQuarantineReason `json:"quarantineReason,omitempty"`
+	// quarantineMessage describes why the machine is quarantined.
+	// +optional
+	QuarantineMessage string `json:"quarantineMessage,omitempty"`
+	// lastProbeTime is the time the machine was last probed.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// hostKey is the SSH host key of the machine accepted on the first probe with the TrustOnFirstUse host key policy,
+	// in the authorized_keys format. The later probes only accept this key.
+	// +optional
+	HostKey string `json:"hostKey,omitempty"`
+	// bastionHostKey is the SSH host key of the bastion accepted on the first probe with the TrustOnFirstUse host key
+	// policy, in the authorized_keys format.
+	// +optional
+	BastionHostKey string `json:"bastionHostKey,omitempty"`
+}
+
+// Quarantine quarantines the machine for the given reason.
+func (s *PooledRemoteMachineStatus) Quarantine(reason PoolQuarantineReason, message string) {
+	s.Quarantined = true
+	s.QuarantineReason = reason
+	s.QuarantineMessage = message
+}
+
+// Release releases the machine from quarantine.
+func (s *PooledRemoteMachineStatus) Release() {
+	s.Quarantined = false
+	s.QuarantineReason = ""
+	s.QuarantineMessage = ""
 }
 
 // RemoteMachineRef is a reference to a RemoteMachine that has been reserved for use.
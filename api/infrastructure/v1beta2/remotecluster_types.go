@@ -49,6 +49,11 @@ type RemoteClusterSpec struct {
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
+
+	// Pools lists the pools of PooledRemoteMachines whose failure domains are published in the status. Defaults to all
+	// the pools of the namespace.
+	// +optional
+	Pools []string `json:"pools,omitempty"`
 }
 
 // RemoteClusterStatus defines the observed state of RemoteCluster
@@ -60,6 +65,12 @@ type RemoteClusterStatus struct {
 	// conditions contains the conditions of the RemoteCluster, which represent the current state of the cluster.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// failureDomains lists the failure domains of the PooledRemoteMachines of the cluster pools. The Machines, including
+	// the control plane ones, are spread across them.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	FailureDomains []clusterv1.FailureDomain `json:"failureDomains,omitempty"`
 }
 
 // GetConditions returns the set of conditions for this object.
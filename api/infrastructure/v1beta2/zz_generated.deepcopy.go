@@ -21,21 +21,171 @@ limitations under the License.
 package v1beta2
 
 import (
-	"k8s.io/api/batch/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	corev1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bastion) DeepCopyInto(out *Bastion) {
+	*out = *in
+	if in.SSHKeyRef != nil {
+		in, out := &in.SSHKeyRef, &out.SSHKeyRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bastion.
+func (in *Bastion) DeepCopy() *Bastion {
+	if in == nil {
+		return nil
+	}
+	out := new(Bastion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapStep) DeepCopyInto(out *BootstrapStep) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapStep.
+func (in *BootstrapStep) DeepCopy() *BootstrapStep {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cleanup) DeepCopyInto(out *Cleanup) {
+	*out = *in
+	if in.PreResetCommands != nil {
+		in, out := &in.PreResetCommands, &out.PreResetCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResetFlags != nil {
+		in, out := &in.ResetFlags, &out.ResetFlags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraPathsToRemove != nil {
+		in, out := &in.ExtraPathsToRemove, &out.ExtraPathsToRemove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryTimeout != nil {
+		in, out := &in.RetryTimeout, &out.RetryTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cleanup.
+func (in *Cleanup) DeepCopy() *Cleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(Cleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostKeyRef) DeepCopyInto(out *HostKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostKeyRef.
+func (in *HostKeyRef) DeepCopy() *HostKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(HostKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolHealthCheck) DeepCopyInto(out *PoolHealthCheck) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.CleanPaths != nil {
+		in, out := &in.CleanPaths, &out.CleanPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolHealthCheck.
+func (in *PoolHealthCheck) DeepCopy() *PoolHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PooledMachineSpec) DeepCopyInto(out *PooledMachineSpec) {
 	*out = *in
+	if in.CommandTimeout != nil {
+		in, out := &in.CommandTimeout, &out.CommandTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.CleanUpCommands != nil {
 		in, out := &in.CleanUpCommands, &out.CleanUpCommands
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(Cleanup)
+		(*in).DeepCopyInto(*out)
+	}
 	out.SSHKeyRef = in.SSHKeyRef
+	if in.HostKeyRef != nil {
+		in, out := &in.HostKeyRef, &out.HostKeyRef
+		*out = new(HostKeyRef)
+		**out = **in
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(Bastion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WinRM != nil {
+		in, out := &in.WinRM, &out.WinRM
+		*out = new(WinRM)
+		**out = **in
+	}
+	if in.PowerManagement != nil {
+		in, out := &in.PowerManagement, &out.PowerManagement
+		*out = new(PowerManagement)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PooledMachineSpec.
@@ -54,7 +204,7 @@ func (in *PooledRemoteMachine) DeepCopyInto(out *PooledRemoteMachine) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PooledRemoteMachine.
@@ -111,6 +261,11 @@ func (in *PooledRemoteMachineList) DeepCopyObject() runtime.Object {
 func (in *PooledRemoteMachineSpec) DeepCopyInto(out *PooledRemoteMachineSpec) {
 	*out = *in
 	in.Machine.DeepCopyInto(&out.Machine)
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(PoolHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PooledRemoteMachineSpec.
@@ -127,6 +282,15 @@ func (in *PooledRemoteMachineSpec) DeepCopy() *PooledRemoteMachineSpec {
 func (in *PooledRemoteMachineStatus) DeepCopyInto(out *PooledRemoteMachineStatus) {
 	*out = *in
 	out.MachineRef = in.MachineRef
+	if in.LastMachineRef != nil {
+		in, out := &in.LastMachineRef, &out.LastMachineRef
+		*out = new(RemoteMachineRef)
+		**out = **in
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PooledRemoteMachineStatus.
@@ -139,12 +303,28 @@ func (in *PooledRemoteMachineStatus) DeepCopy() *PooledRemoteMachineStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerManagement) DeepCopyInto(out *PowerManagement) {
+	*out = *in
+	out.Redfish = in.Redfish
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerManagement.
+func (in *PowerManagement) DeepCopy() *PowerManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProvisionJob) DeepCopyInto(out *ProvisionJob) {
 	*out = *in
 	if in.JobTemplate != nil {
 		in, out := &in.JobTemplate, &out.JobTemplate
-		*out = new(v1.JobTemplateSpec)
+		*out = new(batchv1.JobTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -159,12 +339,28 @@ func (in *ProvisionJob) DeepCopy() *ProvisionJob {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redfish) DeepCopyInto(out *Redfish) {
+	*out = *in
+	out.CredentialsRef = in.CredentialsRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redfish.
+func (in *Redfish) DeepCopy() *Redfish {
+	if in == nil {
+		return nil
+	}
+	out := new(Redfish)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -242,6 +438,11 @@ func (in *RemoteClusterList) DeepCopyObject() runtime.Object {
 func (in *RemoteClusterSpec) DeepCopyInto(out *RemoteClusterSpec) {
 	*out = *in
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterSpec.
@@ -260,7 +461,14 @@ func (in *RemoteClusterStatus) DeepCopyInto(out *RemoteClusterStatus) {
 	in.Initialization.DeepCopyInto(&out.Initialization)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]corev1beta2.FailureDomain, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -339,7 +547,7 @@ func (in *RemoteClusterTemplateList) DeepCopyObject() runtime.Object {
 func (in *RemoteClusterTemplateResource) DeepCopyInto(out *RemoteClusterTemplateResource) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterTemplateResource.
@@ -465,12 +673,47 @@ func (in *RemoteMachineRef) DeepCopy() *RemoteMachineRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteMachineSpec) DeepCopyInto(out *RemoteMachineSpec) {
 	*out = *in
+	if in.PoolSelector != nil {
+		in, out := &in.PoolSelector, &out.PoolSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommandTimeout != nil {
+		in, out := &in.CommandTimeout, &out.CommandTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	out.SSHKeyRef = in.SSHKeyRef
+	if in.HostKeyRef != nil {
+		in, out := &in.HostKeyRef, &out.HostKeyRef
+		*out = new(HostKeyRef)
+		**out = **in
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(Bastion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WinRM != nil {
+		in, out := &in.WinRM, &out.WinRM
+		*out = new(WinRM)
+		**out = **in
+	}
+	if in.PowerManagement != nil {
+		in, out := &in.PowerManagement, &out.PowerManagement
+		*out = new(PowerManagement)
+		**out = **in
+	}
 	if in.CleanUpCommands != nil {
 		in, out := &in.CleanUpCommands, &out.CleanUpCommands
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(Cleanup)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProvisionJob != nil {
 		in, out := &in.ProvisionJob, &out.ProvisionJob
 		*out = new(ProvisionJob)
@@ -499,11 +742,22 @@ func (in *RemoteMachineStatus) DeepCopyInto(out *RemoteMachineStatus) {
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BootstrapSteps != nil {
+		in, out := &in.BootstrapSteps, &out.BootstrapSteps
+		*out = make([]BootstrapStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastPowerCycleTime != nil {
+		in, out := &in.LastPowerCycleTime, &out.LastPowerCycleTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(RemoteMachineStatusDeprecated)
@@ -634,11 +888,21 @@ func (in *RemoteMachineTemplateResource) DeepCopy() *RemoteMachineTemplateResour
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteMachineTemplateResourceSpec) DeepCopyInto(out *RemoteMachineTemplateResourceSpec) {
 	*out = *in
+	if in.PoolSelector != nil {
+		in, out := &in.PoolSelector, &out.PoolSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProvisionJob != nil {
 		in, out := &in.ProvisionJob, &out.ProvisionJob
 		*out = new(ProvisionJob)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(Bastion)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMachineTemplateResourceSpec.
@@ -681,3 +945,19 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WinRM) DeepCopyInto(out *WinRM) {
+	*out = *in
+	out.CredentialsRef = in.CredentialsRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WinRM.
+func (in *WinRM) DeepCopy() *WinRM {
+	if in == nil {
+		return nil
+	}
+	out := new(WinRM)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -55,7 +55,7 @@ func (jtr *JoinTokenRequest) ConvertTo(dstRaw conversion.Hub) error {
 }
 
 // ConvertFrom converts from the hub version (v1beta2) to this JoinTokenRequest (v1beta1).
-// Conditions have no equivalent in v1beta1 and are silently dropped.
+// Conditions, the token renewal and the join tracking fields have no equivalent in v1beta1 and are silently dropped.
 func (jtr *JoinTokenRequest) ConvertFrom(srcRaw conversion.Hub) error {
 	src, ok := srcRaw.(*v2.JoinTokenRequest)
 	if !ok {
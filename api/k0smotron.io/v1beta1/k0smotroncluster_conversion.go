@@ -17,21 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
-	"encoding/json"
 	"fmt"
+	"maps"
 
 	v2 "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
-// Annotation keys used to persist v1beta1 Service fields that have no equivalent in v1beta2.
-const (
-	ServiceAnnotationLabels                = "k0smotron.io/conversion-dropped-service.labels"
-	ServiceAnnotationAnnotations           = "k0smotron.io/conversion-dropped-service.annotations"
-	ServiceAnnotationExternalTrafficPolicy = "k0smotron.io/conversion-dropped-service.externalTrafficPolicy"
-	ServiceAnnotationLoadBalancerClass     = "k0smotron.io/conversion-dropped-service.loadBalancerClass"
-)
-
 var _ conversion.Convertible = &Cluster{}
 
 // ConvertTo converts this Cluster (v1beta1) to the hub version (v1beta2).
@@ -41,22 +33,9 @@ func (kmc *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 		return fmt.Errorf("expected *v2.Cluster, got %T", dstRaw)
 	}
 
-	dst.ObjectMeta = kmc.ObjectMeta
-	v1beta2Spec, nonSupportedFields := ClusterSpecToV2(kmc.Spec)
-	dst.Spec = v1beta2Spec
-
-	if len(nonSupportedFields) > 0 {
-		if dst.Annotations == nil {
-			dst.Annotations = make(map[string]string)
-		}
-		for key, value := range nonSupportedFields {
-			encoded, err := json.Marshal(value)
-			if err != nil {
-				return fmt.Errorf("error encoding annotation %s: %w", key, err)
-			}
-			dst.Annotations[key] = string(encoded)
-		}
-	}
+	dst.ObjectMeta = *kmc.ObjectMeta.DeepCopy()
+	dst.Spec = ClusterSpecToV2(kmc.Spec)
+	v2.MigrateServiceAnnotations(dst.Annotations, &dst.Spec.Service)
 
 	dst.SetReconciliationStatus(kmc.Status.ReconciliationStatus)
 	dst.SetReadyStatus(kmc.Status.Ready)
@@ -87,7 +66,7 @@ func (kmc *Cluster) ConvertFrom(srcRaw conversion.Hub) error {
 }
 
 // ClusterSpecToV2 converts a v1beta1 ClusterSpec to a v1beta2 ClusterSpec.
-func ClusterSpecToV2(spec ClusterSpec) (v2.ClusterSpec, map[string]any) {
+func ClusterSpecToV2(spec ClusterSpec) v2.ClusterSpec {
 	v1beta2Spec := v2.ClusterSpec{
 		Replicas:                  spec.Replicas,
 		Image:                     spec.Image,
@@ -108,25 +87,14 @@ func ClusterSpecToV2(spec ClusterSpec) (v2.ClusterSpec, map[string]any) {
 		KubeconfigSecretMetadata:  spec.KubeconfigSecretMetadata,
 	}
 
-	svcSpec := v2.ServiceSpec{
-		Type:             spec.Service.Type,
-		APIPort:          spec.Service.APIPort,
-		KonnectivityPort: spec.Service.KonnectivityPort,
-	}
-	v1beta2Spec.Service = svcSpec
-
-	nonSupportedFields := map[string]any{}
-	if spec.Service.Labels != nil {
-		nonSupportedFields[ServiceAnnotationLabels] = spec.Service.Labels
-	}
-	if spec.Service.Annotations != nil {
-		nonSupportedFields[ServiceAnnotationAnnotations] = spec.Service.Annotations
-	}
-	if spec.Service.ExternalTrafficPolicy != "" {
-		nonSupportedFields[ServiceAnnotationExternalTrafficPolicy] = spec.Service.ExternalTrafficPolicy
-	}
-	if spec.Service.LoadBalancerClass != nil {
-		nonSupportedFields[ServiceAnnotationLoadBalancerClass] = spec.Service.LoadBalancerClass
+	v1beta2Spec.Service = v2.ServiceSpec{
+		Type:                  spec.Service.Type,
+		APIPort:               spec.Service.APIPort,
+		KonnectivityPort:      spec.Service.KonnectivityPort,
+		Annotations:           spec.Service.Annotations,
+		Labels:                spec.Service.Labels,
+		LoadBalancerClass:     spec.Service.LoadBalancerClass,
+		ExternalTrafficPolicy: spec.Service.ExternalTrafficPolicy,
 	}
 
 	if spec.KubeconfigRef != nil {
@@ -135,8 +103,7 @@ func ClusterSpecToV2(spec ClusterSpec) (v2.ClusterSpec, map[string]any) {
 		}
 	}
 
-	return v1beta2Spec, nonSupportedFields
-
+	return v1beta2Spec
 }
 
 // ClusterSpecFromV2 converts a v1beta2 ClusterSpec to a v1beta1 ClusterSpec.
@@ -169,16 +136,17 @@ func ClusterSpecFromV2(src v2.ClusterSpec, srcAnnotations map[string]string) (Cl
 		spec.KubeconfigRef = src.RemoteHostCluster.KubeconfigRef
 	}
 
+	// Objects not updated since the Service fields were added to v1beta2 may still carry them as annotations.
+	service := *src.Service.DeepCopy()
+	v2.MigrateServiceAnnotations(maps.Clone(srcAnnotations), &service)
 	spec.Service = ServiceSpec{
-		Type:             src.Service.Type,
-		APIPort:          src.Service.APIPort,
-		KonnectivityPort: src.Service.KonnectivityPort,
-	}
-	if len(srcAnnotations) > 0 {
-		spec.Service.Labels = GetServiceLabels(srcAnnotations)
-		spec.Service.Annotations = GetServiceAnnotations(srcAnnotations)
-		spec.Service.ExternalTrafficPolicy = GetServiceExternalTrafficPolicy(srcAnnotations)
-		spec.Service.LoadBalancerClass = GetServiceLoadBalancerClass(srcAnnotations)
+		Type:                  service.Type,
+		APIPort:               service.APIPort,
+		KonnectivityPort:      service.KonnectivityPort,
+		Annotations:           service.Annotations,
+		Labels:                service.Labels,
+		LoadBalancerClass:     service.LoadBalancerClass,
+		ExternalTrafficPolicy: service.ExternalTrafficPolicy,
 	}
 	return spec, nil
 }
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// EtcdRestoreInProgressAnnotation is set on the Cluster while an EtcdRestore is running. The value is the name of the
+	// EtcdRestore. While the annotation is present the Cluster controller does not reconcile the k0s and etcd StatefulSets.
+	EtcdRestoreInProgressAnnotation = "k0smotron.io/etcd-restore-in-progress"
+
+	// EtcdRestoreCompletedCondition surfaces details about the progress of the restore.
+	EtcdRestoreCompletedCondition = "RestoreCompleted"
+	// EtcdRestoreInProgressReason surfaces when the restore is still running.
+	EtcdRestoreInProgressReason = "InProgress"
+	// EtcdRestoreSucceededReason surfaces when the restore has finished and the control plane is back.
+	EtcdRestoreSucceededReason = "Succeeded"
+	// EtcdRestoreFailedReason surfaces when the restore cannot proceed.
+	EtcdRestoreFailedReason = "Failed"
+)
+
+// EtcdRestorePhase describes the step an EtcdRestore is in.
+type EtcdRestorePhase string
+
+const (
+	// EtcdRestorePhasePending means the restore has not started yet.
+	EtcdRestorePhasePending EtcdRestorePhase = ""
+	// EtcdRestorePhaseScalingDown means the k0s and etcd StatefulSets are being scaled to zero.
+	EtcdRestorePhaseScalingDown EtcdRestorePhase = "ScalingDown"
+	// EtcdRestorePhaseRestoring means the etcd members are being seeded from the snapshot.
+	EtcdRestorePhaseRestoring EtcdRestorePhase = "Restoring"
+	// EtcdRestorePhaseScalingUp means etcd and then k0s are being scaled back up.
+	EtcdRestorePhaseScalingUp EtcdRestorePhase = "ScalingUp"
+	// EtcdRestorePhaseCompleted means the restore has finished.
+	EtcdRestorePhaseCompleted EtcdRestorePhase = "Completed"
+	// EtcdRestorePhaseFailed means the restore cannot proceed and needs manual intervention.
+	EtcdRestorePhaseFailed EtcdRestorePhase = "Failed"
+)
+
+// EtcdRestoreSpec defines the desired state of EtcdRestore
+type EtcdRestoreSpec struct {
+	// clusterName is the name of the k0smotron Cluster to restore. The Cluster must use the etcd storage type.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ClusterName string `json:"clusterName,omitempty"`
+	// Source defines where the snapshot is read from. Exactly one of pvc or s3 must be set.
+	// +required
+	Source EtcdSnapshotLocation `json:"source"`
+	// Snapshot is the file name (pvc) or object key relative to the prefix (s3) of the snapshot to restore.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Snapshot string `json:"snapshot"`
+}
+
+// EtcdRestoreStatus defines the observed state of EtcdRestore
+type EtcdRestoreStatus struct {
+	// Phase is the current step of the restore.
+	// +optional
+	Phase EtcdRestorePhase `json:"phase,omitempty"`
+	// EtcdReplicas is the number of etcd members the restore seeds, captured before scaling down.
+	// +optional
+	EtcdReplicas int32 `json:"etcdReplicas,omitempty"`
+	// Replicas is the number of k0s control plane replicas captured before scaling down.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// StartTime is the time the restore started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the restore finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Conditions represents the observations of the restore state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:storageversion
+//+kubebuilder:resource:shortName=etcdr
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+
+// EtcdRestore is the Schema for the etcd restore API. It restores the etcd members of a k0smotron Cluster
+// from a snapshot taken by the scheduled etcd backup job.
+type EtcdRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdRestoreSpec   `json:"spec,omitempty"`
+	Status EtcdRestoreStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the conditions of the EtcdRestore status.
+func (r *EtcdRestore) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the conditions on the EtcdRestore status.
+func (r *EtcdRestore) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// EtcdRestoreList contains a list of EtcdRestore
+type EtcdRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdRestore{}, &EtcdRestoreList{})
+}
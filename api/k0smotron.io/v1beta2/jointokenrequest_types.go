@@ -29,6 +29,11 @@ const (
 	// JoinTokenRequestInternalErrorReason is the reason used in the condition when the join token request controller encounters an internal error
 	// while processing the request.
 	JoinTokenRequestInternalErrorReason = "InternalError"
+	// JoinTokenRequestTokenValidCondition is the condition type used to indicate whether the token can still be used to join nodes.
+	JoinTokenRequestTokenValidCondition = "TokenValid"
+	// JoinTokenRequestMaxJoinsReachedReason is the reason used in the condition when the token has been invalidated because the
+	// maximum number of joins has been reached.
+	JoinTokenRequestMaxJoinsReachedReason = "MaxJoinsReached"
 	// V1Beta1ClusterRefNamespaceAnnotation is the temporary annotation used to store the namespace of the cluster reference, since in v1beta1 the namespace was part
 	// of the spec and in v1beta2 it's not.
 	V1Beta1ClusterRefNamespaceAnnotation = "k0smotron.io/cluster-ref-namespace"
@@ -49,6 +54,22 @@ type JoinTokenRequestSpec struct {
 	//+kubebuilder:validation:Enum=worker;controller
 	//+kubebuilder:default=worker
 	Role string `json:"role,omitempty"`
+	// renewBefore enables the renewal of the token: a new token is created and stored in the Secret this long before
+	// the current one expires, and the previous token is invalidated after a grace period. Requires a non-zero expiry
+	// longer than renewBefore.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// maxJoins is the maximum number of nodes allowed to join the cluster with the token. The token is invalidated
+	// once the number of joined nodes reaches it. Setting it enables the tracking of the joins. Only supported for the
+	// worker role, as the token a controller joined with is not recorded.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxJoins *int32 `json:"maxJoins,omitempty"`
+	// trackJoins enables the tracking of the nodes joining the cluster with the token in status.joinedNodes, by
+	// watching the hosted cluster while the token is valid. The joins of controller tokens are reported on a best
+	// effort basis: every controller joining while the token is valid is listed.
+	// +optional
+	TrackJoins bool `json:"trackJoins,omitempty"`
 }
 
 // ClusterRef is a reference to a cluster for which a join token is requested.
@@ -62,6 +83,19 @@ type ClusterRef struct {
 // JoinTokenRequestStatus defines the observed state of K0smotronJoinTokenRequest
 type JoinTokenRequestStatus struct {
 	TokenID string `json:"tokenID,omitempty"`
+	// previousTokenID is the ID of the token replaced by the last renewal, until it is invalidated.
+	// +optional
+	PreviousTokenID string `json:"previousTokenID,omitempty"`
+	// expiresAt is the time the current token expires, unset if the token does not expire.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// lastRotationTime is the last time the token was renewed.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// joinedNodes lists the nodes which joined the cluster with the tokens of the request. Only tracked when trackJoins
+	// or maxJoins is set.
+	// +optional
+	JoinedNodes []JoinedNode `json:"joinedNodes,omitempty"`
 	// Conditions represents the observations of the k0smotron cluster's state.
 	// Known condition types are Available, Deleting.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -70,6 +104,17 @@ type JoinTokenRequestStatus struct {
 	Deprecated *JTRStatusDeprecated `json:"deprecated,omitempty"`
 }
 
+// JoinedNode is a node which joined the cluster with a token of a JoinTokenRequest.
+type JoinedNode struct {
+	// name is the name of the node.
+	Name string `json:"name"`
+	// tokenID is the ID of the token the node joined with.
+	TokenID string `json:"tokenID"`
+	// joinTime is the time the node requested its kubelet certificate with the token, or the time a controller
+	// registered its ControlNode.
+	JoinTime metav1.Time `json:"joinTime"`
+}
+
 // JTRStatusDeprecated defines the observed state of K0smotronJoinTokenRequest for deprecated fields, which will be removed in future versions.
 type JTRStatusDeprecated struct {
 	// v1beta1 groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
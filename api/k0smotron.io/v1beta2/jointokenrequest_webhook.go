@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-k0smotron-io-v1beta2-jointokenrequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=k0smotron.io,resources=jointokenrequests,verbs=create;update,versions=v1beta2,name=validate-k0smotron-jointokenrequest-v1beta2.k0smotron.io,admissionReviewVersions=v1
+
+// JoinTokenRequestValidator is a webhook that validates the JoinTokenRequest resource.
+type JoinTokenRequestValidator struct{}
+
+var _ webhook.CustomValidator = &JoinTokenRequestValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type JoinTokenRequest.
+func (v JoinTokenRequestValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	jtr, ok := obj.(*JoinTokenRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected a JoinTokenRequest object but got %T", obj)
+	}
+
+	return nil, validateJoinTokenRequestSpec(&jtr.Spec)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type JoinTokenRequest.
+func (v JoinTokenRequestValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	jtr, ok := newObj.(*JoinTokenRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected a JoinTokenRequest object but got %T", newObj)
+	}
+
+	return nil, validateJoinTokenRequestSpec(&jtr.Spec)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type JoinTokenRequest.
+func (v JoinTokenRequestValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateJoinTokenRequestSpec rejects maxJoins for controller tokens: k0s does not record the token a controller
+// joined with, so controllers joined with other tokens would use up the joins allowed and get the token invalidated.
+func validateJoinTokenRequestSpec(spec *JoinTokenRequestSpec) error {
+	if spec.MaxJoins != nil && spec.Role == "controller" {
+		return fmt.Errorf("maxJoins is only supported for the worker role")
+	}
+	return nil
+}
+
+// SetupJoinTokenRequestWebhookWithManager registers the webhook for JoinTokenRequest in the manager.
+func SetupJoinTokenRequestWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&JoinTokenRequest{}).
+		WithValidator(&JoinTokenRequestValidator{}).
+		Complete()
+}
@@ -0,0 +1,41 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinTokenRequestValidator(t *testing.T) {
+	v := JoinTokenRequestValidator{}
+	jtr := &JoinTokenRequest{Spec: JoinTokenRequestSpec{Role: "worker", MaxJoins: new(int32(3))}}
+	_, err := v.ValidateCreate(context.Background(), jtr)
+	require.NoError(t, err)
+
+	// The joins of controller tokens can only be tracked.
+	jtr.Spec.Role = "controller"
+	_, err = v.ValidateCreate(context.Background(), jtr)
+	require.ErrorContains(t, err, "maxJoins is only supported for the worker role")
+	jtr.Spec.MaxJoins = nil
+	jtr.Spec.TrackJoins = true
+	_, err = v.ValidateUpdate(context.Background(), jtr, jtr)
+	require.NoError(t, err)
+}
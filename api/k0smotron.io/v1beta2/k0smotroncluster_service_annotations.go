@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Annotation keys used by earlier releases to persist the v1beta1 Service fields v1beta2 had no equivalent for. They
+// are migrated into spec.service by MigrateServiceAnnotations.
+const (
+	ServiceAnnotationLabels                = "k0smotron.io/conversion-dropped-service.labels"
+	ServiceAnnotationAnnotations           = "k0smotron.io/conversion-dropped-service.annotations"
+	ServiceAnnotationExternalTrafficPolicy = "k0smotron.io/conversion-dropped-service.externalTrafficPolicy"
+	ServiceAnnotationLoadBalancerClass     = "k0smotron.io/conversion-dropped-service.loadBalancerClass"
+)
+
+// MigrateServiceAnnotations moves the Service settings persisted in the deprecated annotations into the ServiceSpec,
+// without overriding the fields already set, and removes the annotations. It returns true if any annotation was found.
+func MigrateServiceAnnotations(annotations map[string]string, service *ServiceSpec) bool {
+	found := false
+
+	var labels map[string]string
+	if popServiceAnnotation(annotations, ServiceAnnotationLabels, &labels) {
+		found = true
+		if service.Labels == nil {
+			service.Labels = labels
+		}
+	}
+
+	var serviceAnnotations map[string]string
+	if popServiceAnnotation(annotations, ServiceAnnotationAnnotations, &serviceAnnotations) {
+		found = true
+		if service.Annotations == nil {
+			service.Annotations = serviceAnnotations
+		}
+	}
+
+	var externalTrafficPolicy string
+	if popServiceAnnotation(annotations, ServiceAnnotationExternalTrafficPolicy, &externalTrafficPolicy) {
+		found = true
+		if service.ExternalTrafficPolicy == "" {
+			service.ExternalTrafficPolicy = v1.ServiceExternalTrafficPolicyType(externalTrafficPolicy)
+		}
+	}
+
+	var loadBalancerClass string
+	if popServiceAnnotation(annotations, ServiceAnnotationLoadBalancerClass, &loadBalancerClass) {
+		found = true
+		if service.LoadBalancerClass == nil && loadBalancerClass != "" {
+			service.LoadBalancerClass = &loadBalancerClass
+		}
+	}
+
+	return found
+}
+
+// popServiceAnnotation decodes the JSON value of the annotation into out and removes the annotation. It returns
+// false if the annotation is not set. A value that can't be decoded is dropped.
+func popServiceAnnotation(annotations map[string]string, key string, out any) bool {
+	val, ok := annotations[key]
+	if !ok {
+		return false
+	}
+	delete(annotations, key)
+	if val != "" {
+		_ = json.Unmarshal([]byte(val), out)
+	}
+	return true
+}
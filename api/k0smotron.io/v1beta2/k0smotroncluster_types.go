@@ -24,12 +24,23 @@ import (
 	"github.com/k0sproject/version"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+const (
+	// RotateCertificatesAnnotation requests the rotation of the etcd server, peer and client certificates when set on
+	// a Cluster. The annotation is removed once the new certificates have been generated.
+	RotateCertificatesAnnotation = "k0smotron.io/rotate-certificates"
+
+	// CertificatesRotatedAtAnnotation is set on the etcd and control plane pod templates to restart the pods
+	// after a certificate rotation.
+	CertificatesRotatedAtAnnotation = "k0smotron.io/certificates-rotated-at"
+)
+
 const (
 	// ClusterControlPlaneFunctionalCondition surfaces details about the functionality of the control plane.
 	ClusterControlPlaneFunctionalCondition = "ControlPlaneFunctional"
@@ -90,6 +101,31 @@ const (
 	// ClusterCertificatesAvailableCondition surfaces details about the availability of the certificates for the workload cluster.
 	ClusterCertificatesAvailableCondition = "CertificatesAvailable"
 
+	// ClusterCertificatesExpiringSoonCondition surfaces whether any of the certificates of the cluster expires within the
+	// warning window.
+	ClusterCertificatesExpiringSoonCondition = "CertificatesExpiringSoon"
+
+	// CertificatesExpiringSoonReason surfaces when at least one certificate expires within the warning window.
+	CertificatesExpiringSoonReason = "ExpiringSoon"
+
+	// CertificatesNotExpiringSoonReason surfaces when no certificate expires within the warning window.
+	CertificatesNotExpiringSoonReason = "NotExpiringSoon"
+
+	// ClusterSuspendedCondition surfaces whether the control plane is suspended, i.e. scaled to zero on purpose.
+	ClusterSuspendedCondition = "Suspended"
+
+	// ClusterSuspendingReason surfaces when the control plane is being scaled down to zero.
+	ClusterSuspendingReason = "Suspending"
+
+	// ClusterSuspendedReason surfaces when all the control plane replicas are scaled down.
+	ClusterSuspendedReason = "Suspended"
+
+	// ClusterResumingReason surfaces when the control plane is scaling back up after a suspension.
+	ClusterResumingReason = "Resuming"
+
+	// ClusterNotSuspendedReason surfaces when the control plane is running.
+	ClusterNotSuspendedReason = "NotSuspended"
+
 	// ClusterAvailableCondition surfaces details about the overall availability of the cluster meaning ControlPlaneFunctionalCondition is true,
 	// ClusterKubeconfigSecretAvailableCondition is true and ClusterControlPlaneUpToDateCondition is true.
 	ClusterAvailableCondition = "Available"
@@ -131,6 +167,10 @@ type ClusterSpec struct {
 	// Ingress defines the ingress configuration.
 	//+kubebuilder:validation:Optional
 	Ingress *IngressSpec `json:"ingress,omitempty"`
+	// Gateway defines the Gateway API configuration. It is an alternative to Ingress, routing the host names to the
+	// cluster with TLSRoutes instead of an Ingress resource.
+	//+kubebuilder:validation:Optional
+	Gateway *GatewaySpec `json:"gateway,omitempty"`
 	// Service defines the service configuration.
 	//+kubebuilder:validation:Optional
 	//+kubebuilder:default={"type":"ClusterIP","apiPort":30443,"konnectivityPort":30132}
@@ -191,6 +231,190 @@ type ClusterSpec struct {
 	// For the full list of generated resources and their component labels, see https://docs.k0smotron.io/stable/generated-resources/.
 	// +kubebuilder:validation:Optional
 	Patches []ComponentPatch `json:"patches,omitempty"`
+	// Autoscaling enables horizontal autoscaling of the control plane. When set, k0smotron creates a
+	// HorizontalPodAutoscaler targeting this Cluster and spec.replicas is managed by it.
+	// Autoscaling is not supported together with remoteHostCluster or the NATS storage type.
+	// +kubebuilder:validation:Optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// Authentication defines how the kube-apiserver authenticates users. It is rendered as an
+	// AuthenticationConfiguration file and requires Kubernetes v1.30 or newer.
+	// +kubebuilder:validation:Optional
+	Authentication *AuthenticationSpec `json:"authentication,omitempty"`
+	// Audit configures audit logging of the kube-apiserver.
+	// +kubebuilder:validation:Optional
+	Audit *AuditSpec `json:"audit,omitempty"`
+	// Suspended scales the control plane, including etcd, down to zero replicas while keeping its data, certificates
+	// and services. Setting it back to false resumes the control plane with spec.replicas replicas.
+	// +kubebuilder:validation:Optional
+	Suspended bool `json:"suspended,omitempty"`
+}
+
+// AuditSpec defines the audit logging configuration of the kube-apiserver. At least one of the log and webhook
+// backends must be configured.
+type AuditSpec struct {
+	// Policy references the ConfigMap holding the audit policy. The ConfigMap must exist in the cluster where the
+	// control plane pods run.
+	// +kubebuilder:validation:Required
+	Policy AuditPolicyRef `json:"policy"`
+	// Log configures the log backend, which writes the audit events to a file on the k0s data volume.
+	// +kubebuilder:validation:Optional
+	Log *AuditLogBackend `json:"log,omitempty"`
+	// Webhook configures the webhook backend, which sends the audit events to an external API.
+	// +kubebuilder:validation:Optional
+	Webhook *AuditWebhookBackend `json:"webhook,omitempty"`
+}
+
+// AuditPolicyRef references the key of a ConfigMap holding an audit policy.
+type AuditPolicyRef struct {
+	// Name is the name of the ConfigMap.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key is the key holding the policy.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="policy.yaml"
+	Key string `json:"key,omitempty"`
+}
+
+// AuditLogBackend defines the audit log backend.
+type AuditLogBackend struct {
+	// MaxSize is the maximum size in megabytes of the audit log file before it gets rotated.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=100
+	MaxSize int32 `json:"maxSize,omitempty"`
+	// MaxBackups is the maximum number of rotated audit log files to retain.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=10
+	MaxBackups int32 `json:"maxBackups,omitempty"`
+	// MaxAge is the maximum number of days to retain rotated audit log files.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=30
+	MaxAge int32 `json:"maxAge,omitempty"`
+	// StreamToStdout adds a sidecar container printing the audit log to its standard output, so the audit events
+	// can be shipped with the pod logs.
+	// +kubebuilder:validation:Optional
+	StreamToStdout bool `json:"streamToStdout,omitempty"`
+}
+
+// AuditWebhookBackend defines the audit webhook backend.
+type AuditWebhookBackend struct {
+	// ConfigSecretRef references the key of a Secret holding the kubeconfig file describing the webhook. The
+	// Secret must exist in the cluster where the control plane pods run.
+	// +kubebuilder:validation:Required
+	ConfigSecretRef AuditWebhookConfigRef `json:"configSecretRef"`
+	// Mode is the strategy for sending audit events.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=batch;blocking;blocking-strict
+	// +kubebuilder:default=batch
+	Mode string `json:"mode,omitempty"`
+	// InitialBackoff is the time to wait before retrying the first failed request.
+	// +kubebuilder:validation:Optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+}
+
+// AuditWebhookConfigRef references the key of a Secret holding the audit webhook kubeconfig.
+type AuditWebhookConfigRef struct {
+	// Name is the name of the Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key is the key holding the kubeconfig.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="kubeconfig"
+	Key string `json:"key,omitempty"`
+}
+
+// AuthenticationSpec defines the authentication configuration of the kube-apiserver.
+type AuthenticationSpec struct {
+	// OIDC configures the kube-apiserver to authenticate users with tokens issued by an OpenID Connect provider.
+	// +kubebuilder:validation:Optional
+	OIDC *OIDCSpec `json:"oidc,omitempty"`
+}
+
+// OIDCSpec defines the OpenID Connect provider used to authenticate users.
+type OIDCSpec struct {
+	// IssuerURL is the URL of the provider. It must use the https scheme and match the "iss" claim of the tokens.
+	// +kubebuilder:validation:Required
+	IssuerURL string `json:"issuerURL"`
+	// ClientID is the audience the tokens must be issued to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+	// UsernameClaim is the claim used as the user name.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="sub"
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// UsernamePrefix is prepended to the user name to prevent clashes with other authentication methods.
+	// +kubebuilder:validation:Optional
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+	// GroupsClaim is the claim used as the user groups. If empty, groups are not mapped.
+	// +kubebuilder:validation:Optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// GroupsPrefix is prepended to the group names to prevent clashes with other authentication methods.
+	// +kubebuilder:validation:Optional
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+	// CertificateAuthority is the CA bundle used to verify the provider's TLS certificate. If empty, the
+	// system trust store of the control plane image is used.
+	// +kubebuilder:validation:Optional
+	CertificateAuthority *CertificateAuthoritySource `json:"certificateAuthority,omitempty"`
+}
+
+// CertificateAuthoritySource references a PEM encoded CA bundle in a Secret or a ConfigMap in the namespace of
+// the Cluster. Exactly one of SecretRef and ConfigMapRef must be set.
+type CertificateAuthoritySource struct {
+	// SecretRef references a key of a Secret containing the CA bundle.
+	// +kubebuilder:validation:Optional
+	SecretRef *CAKeyRef `json:"secretRef,omitempty"`
+	// ConfigMapRef references a key of a ConfigMap containing the CA bundle.
+	// +kubebuilder:validation:Optional
+	ConfigMapRef *CAKeyRef `json:"configMapRef,omitempty"`
+}
+
+// CAKeyRef references the key of a Secret or a ConfigMap holding a CA bundle.
+type CAKeyRef struct {
+	// Name is the name of the Secret or ConfigMap.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Key is the key holding the value.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="ca.crt"
+	Key string `json:"key,omitempty"`
+}
+
+// AutoscalingSpec defines the horizontal autoscaling configuration for the k0s control plane.
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit for the number of control plane replicas.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of control plane replicas.
+	//+kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the target average CPU utilization of the control plane pods,
+	// relative to their CPU requests. Requires spec.resources.requests.cpu to be set.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// TargetAPIServerRequestRate is the target API server request rate per control plane pod. The metric
+	// must be served through the custom metrics API, for example by prometheus-adapter.
+	//+kubebuilder:validation:Optional
+	TargetAPIServerRequestRate *APIServerRequestRateTarget `json:"targetAPIServerRequestRate,omitempty"`
+	// Behavior configures the scaling behavior of the HorizontalPodAutoscaler. If unset, k0smotron adds at most
+	// one replica per minute so that etcd members join one at a time.
+	//+kubebuilder:validation:Optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// APIServerRequestRateTarget defines the API server request rate target for autoscaling.
+type APIServerRequestRateTarget struct {
+	// MetricName is the name of the pod metric exposing the API server request rate.
+	//+kubebuilder:default="apiserver_request_rate"
+	MetricName string `json:"metricName,omitempty"`
+	// AverageValue is the target request rate per control plane pod.
+	AverageValue resource.Quantity `json:"averageValue"`
 }
 
 // RemoteHostClusterSpec defines the configuration for deploying the k0s control plane in a remote hosting cluster.
@@ -280,22 +504,31 @@ var ingressCompatibleVersions = []*version.Version{
 	version.MustParse("v1.34.1+k0s.0"),
 }
 
-// Validate checks if the ingress controller is compatible with the given k0s version
-func (i *IngressSpec) Validate(clusterVersion string) (admission.Warnings, error) {
-	warnings := admission.Warnings{}
+// validateIngressCompatibleVersion checks if the given k0s version supports accessing the cluster via host names.
+func validateIngressCompatibleVersion(mode, clusterVersion string) error {
 	v, err := version.NewVersion(clusterVersion)
 	if err != nil {
-		return warnings, fmt.Errorf("failed to parse k0s version %s: %w", clusterVersion, err)
+		return fmt.Errorf("failed to parse k0s version %s: %w", clusterVersion, err)
 	}
 
 	for _, iv := range ingressCompatibleVersions {
 		if v.Segments()[1] == iv.Segments()[1] {
 			if v.Core().LessThan(iv.Core()) {
-				return warnings, fmt.Errorf("ingress controller is not supported with k0s version %s, minimum supported version for ingress is %s", clusterVersion, iv.String())
+				return fmt.Errorf("%s is not supported with k0s version %s, minimum supported version for %s is %s", mode, clusterVersion, mode, iv.String())
 			}
 		}
 	}
 
+	return nil
+}
+
+// Validate checks if the ingress controller is compatible with the given k0s version
+func (i *IngressSpec) Validate(clusterVersion string) (admission.Warnings, error) {
+	warnings := admission.Warnings{}
+	if err := validateIngressCompatibleVersion("ingress controller", clusterVersion); err != nil {
+		return warnings, err
+	}
+
 	if i.Deploy != nil && *i.Deploy && len(i.Annotations) == 0 {
 		warnings = append(warnings, "no annotations specified for the ingress controller, make sure that ingress controller supports tls passthrough")
 	}
@@ -303,6 +536,63 @@ func (i *IngressSpec) Validate(clusterVersion string) (admission.Warnings, error
 	return warnings, nil
 }
 
+// GatewaySpec defines the Gateway API configuration for accessing the Kubernetes API and konnectivity server via host
+// names. A TLSRoute is created for each host name, attached to the given Gateway listeners.
+type GatewaySpec struct {
+	// ParentRefs defines the Gateways the TLSRoutes are attached to. The listeners must use the TLS protocol in
+	// Passthrough mode, the TLS connections are terminated by the control plane.
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+	// Port defines the port of the Gateway listeners.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:default=443
+	Port int64 `json:"port,omitempty"`
+	//+kubebuilder:validation:Required
+	APIHost string `json:"apiHost"`
+	//+kubebuilder:validation:Required
+	KonnectivityHost string `json:"konnectivityHost"`
+	// Annotations defines extra annotations to be added to the TLSRoutes.
+	//+kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GatewayParentReference identifies a Gateway, and optionally one of its listeners, a TLSRoute is attached to.
+type GatewayParentReference struct {
+	// Name is the name of the Gateway.
+	//+kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Namespace is the namespace of the Gateway. Defaults to the namespace of the cluster.
+	//+kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the Gateway listener. Defaults to all the listeners of the Gateway.
+	//+kubebuilder:validation:Optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// Validate checks if the Gateway API mode is compatible with the given k0s version
+func (g *GatewaySpec) Validate(clusterVersion string) error {
+	return validateIngressCompatibleVersion("gateway", clusterVersion)
+}
+
+// GetIngressEndpoint returns the host names and port the cluster is accessed on through an Ingress or a Gateway, or
+// nil if the cluster is accessed through its service. Both modes share the worker-side HAProxy and konnectivity
+// setup, so a Gateway is returned as an IngressSpec not deploying any Ingress resource.
+func (c *ClusterSpec) GetIngressEndpoint() *IngressSpec {
+	if c.Ingress != nil {
+		return c.Ingress
+	}
+	if c.Gateway != nil {
+		return &IngressSpec{
+			Deploy:           new(false),
+			Port:             c.Gateway.Port,
+			APIHost:          c.Gateway.APIHost,
+			KonnectivityHost: c.Gateway.KonnectivityHost,
+		}
+	}
+	return nil
+}
+
 // Mount defines a volume to be mounted in the control plane pod,
 // along with the mount path and read-only flag.
 type Mount struct {
@@ -394,11 +684,51 @@ type ClusterStatus struct {
 	// Conditions represents the observations of the k0smotron cluster's state.
 	// Known condition types are Available, Deleting.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Certificates lists the certificates managed by k0smotron for this cluster together with their expiry dates.
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	// CertificateRotation describes the progress of the last certificate rotation.
+	// +optional
+	CertificateRotation *CertificateRotationStatus `json:"certificateRotation,omitempty"`
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *ClusterStatusDeprecated `json:"deprecated,omitempty"`
 }
 
+// CertificateStatus describes a certificate managed by k0smotron.
+type CertificateStatus struct {
+	// Purpose is the purpose of the certificate, e.g. etcd-server.
+	Purpose string `json:"purpose"`
+	// SecretName is the name of the secret containing the certificate.
+	SecretName string `json:"secretName"`
+	// NotAfter is the expiry date of the certificate.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+// CertificateRotationPhase is the phase of a certificate rotation.
+type CertificateRotationPhase string
+
+const (
+	// CertificateRotationPhaseRestartingEtcd means the certificates have been rotated and the etcd members are being restarted.
+	CertificateRotationPhaseRestartingEtcd CertificateRotationPhase = "RestartingEtcd"
+	// CertificateRotationPhaseRestartingControlPlane means all etcd members run with the rotated certificates and the control plane
+	// pods are being restarted.
+	CertificateRotationPhaseRestartingControlPlane CertificateRotationPhase = "RestartingControlPlane"
+	// CertificateRotationPhaseCompleted means all pods run with the rotated certificates.
+	CertificateRotationPhaseCompleted CertificateRotationPhase = "Completed"
+)
+
+// CertificateRotationStatus describes the progress of a certificate rotation.
+type CertificateRotationStatus struct {
+	// Phase is the current phase of the rotation.
+	Phase CertificateRotationPhase `json:"phase"`
+	// LastRotationTime is the time the certificates were last rotated.
+	LastRotationTime metav1.Time `json:"lastRotationTime"`
+	// ControlPlaneRestartTime is the time the control plane pods were restarted to pick up the rotated certificates.
+	// +optional
+	ControlPlaneRestartTime *metav1.Time `json:"controlPlaneRestartTime,omitempty"`
+}
+
 // ClusterStatusDeprecated defines the observed state of K0smotronCluster for deprecated fields, which will be removed in future versions.
 type ClusterStatusDeprecated struct {
 	// v1beta1 groups all the status fields that are deprecated and will be removed when support for v1beta1 will be dropped.
@@ -482,6 +812,49 @@ type ServiceSpec struct {
 	//+kubebuilder:validation:Optional
 	//+kubebuilder:default=30132
 	KonnectivityPort int `json:"konnectivityPort,omitempty"`
+	// IPFamilies defines the IP families of the service, e.g. [IPv6] for an IPv6-only management cluster or
+	// [IPv4, IPv6] for a dual-stack service. If empty, the cluster default is used.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:MaxItems=2
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// IPFamilyPolicy defines the dual-stack policy of the service. If empty, the cluster default is used.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// Annotations defines extra annotations to be added to the service.
+	//+kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Labels defines extra labels to be added to the service.
+	//+kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// LoadBalancerClass defines the load balancer class to be used for the service. Used only when service type is LoadBalancer.
+	//+kubebuilder:validation:Optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+	// LoadBalancerSourceRanges restricts the client IP ranges allowed to access the load balancer. Used only when
+	// service type is LoadBalancer.
+	//+kubebuilder:validation:Optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// LoadBalancerIP defines the IP address requested for the load balancer, if supported by the load balancer
+	// implementation. Used only when service type is LoadBalancer.
+	//+kubebuilder:validation:Optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+	// ExternalTrafficPolicy defines the external traffic policy for the service. Used only when service type is NodePort or LoadBalancer.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy v1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+	// SessionAffinity defines the session affinity of the service.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=ClientIP;None
+	SessionAffinity v1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+}
+
+// IsDualStack returns true if the service is requested to be dual-stack, in which case the k0s dual-stack
+// networking is enabled as well.
+func (s *ServiceSpec) IsDualStack() bool {
+	if s.IPFamilyPolicy != nil && *s.IPFamilyPolicy == v1.IPFamilyPolicySingleStack {
+		return false
+	}
+	return len(s.IPFamilies) == 2 || (s.IPFamilyPolicy != nil && *s.IPFamilyPolicy == v1.IPFamilyPolicyRequireDualStack)
 }
 
 //+kubebuilder:object:root=true
@@ -631,6 +1004,9 @@ type EtcdSpec struct {
 	// DefragJob defines the etcd defragmentation job configuration.
 	//+kubebuilder:validation:Optional
 	DefragJob DefragJob `json:"defragJob"`
+	// Backup defines the scheduled etcd snapshot configuration.
+	//+kubebuilder:validation:Optional
+	Backup EtcdBackupSpec `json:"backup,omitempty,omitzero"`
 	// Resources defines the compute resource requirements for the etcd container.
 	//+kubebuilder:validation:Optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
@@ -653,6 +1029,78 @@ type DefragJob struct {
 	Image string `json:"image"`
 }
 
+// EtcdBackupSpec defines the configuration for the scheduled etcd snapshot job.
+type EtcdBackupSpec struct {
+	// Enabled enables the etcd snapshot job.
+	//+kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+	// Schedule defines the etcd snapshot job schedule.
+	//+kubebuilder:default="0 0 * * *"
+	Schedule string `json:"schedule,omitempty"`
+	// Retention defines how many snapshots are kept in the destination. Older snapshots are removed
+	// after each successful snapshot.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=7
+	Retention int32 `json:"retention,omitempty"`
+	// Destination defines where the snapshots are stored. Exactly one of pvc or s3 must be set.
+	//+kubebuilder:validation:Optional
+	Destination EtcdSnapshotLocation `json:"destination,omitempty"`
+}
+
+// EtcdSnapshotLocation defines where etcd snapshots are stored. Exactly one of PVC or S3 must be set.
+type EtcdSnapshotLocation struct {
+	// PVC stores the snapshots in an existing PersistentVolumeClaim in the cluster namespace.
+	//+kubebuilder:validation:Optional
+	PVC *EtcdSnapshotPVCLocation `json:"pvc,omitempty"`
+	// S3 stores the snapshots in an S3-compatible bucket.
+	//+kubebuilder:validation:Optional
+	S3 *EtcdSnapshotS3Location `json:"s3,omitempty"`
+}
+
+// EtcdSnapshotPVCLocation defines a PersistentVolumeClaim used to store etcd snapshots.
+type EtcdSnapshotPVCLocation struct {
+	// ClaimName is the name of the PersistentVolumeClaim. The claim must exist in the cluster namespace.
+	//+kubebuilder:validation:Required
+	ClaimName string `json:"claimName"`
+}
+
+// EtcdSnapshotS3Location defines an S3-compatible bucket used to store etcd snapshots.
+type EtcdSnapshotS3Location struct {
+	// Bucket is the name of the bucket.
+	//+kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the snapshot object keys.
+	//+kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+	// Endpoint is the URL of the S3-compatible API. If empty, AWS S3 is used.
+	//+kubebuilder:validation:Optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region is the region of the bucket.
+	//+kubebuilder:validation:Optional
+	Region string `json:"region,omitempty"`
+	// CredentialsSecretName is the name of the secret in the cluster namespace holding the
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	//+kubebuilder:validation:Required
+	CredentialsSecretName string `json:"credentialsSecretName"`
+	// Image defines the image used to transfer snapshots to and from the bucket. The image must provide the aws CLI.
+	//+kubebuilder:default="amazon/aws-cli:2.27.50"
+	Image string `json:"image,omitempty"`
+}
+
+// Validate checks that exactly one snapshot location is set.
+func (l *EtcdSnapshotLocation) Validate() error {
+	if (l.PVC == nil) == (l.S3 == nil) {
+		return fmt.Errorf("exactly one of pvc or s3 must be set")
+	}
+	if l.PVC != nil && l.PVC.ClaimName == "" {
+		return fmt.Errorf("pvc.claimName must be set")
+	}
+	if l.S3 != nil && (l.S3.Bucket == "" || l.S3.CredentialsSecretName == "") {
+		return fmt.Errorf("s3.bucket and s3.credentialsSecretName must be set")
+	}
+	return nil
+}
+
 // StoragePersistenceSpec defines the persistence configuration for storage backends like etcd or NATS.
 type StoragePersistenceSpec struct {
 	// StorageClass defines the storage class to be used. If empty, the default storage class is used.
@@ -726,6 +1174,16 @@ func (kmc *Cluster) GetEtcdDefragJobName() string {
 	return kmc.getObjectName("kmc-%s-defrag")
 }
 
+// GetEtcdBackupJobName returns the name of the etcd snapshot job.
+func (kmc *Cluster) GetEtcdBackupJobName() string {
+	return kmc.getObjectName("kmc-%s-etcd-backup")
+}
+
+// GetEtcdRestoreJobName returns the name of the job restoring the given etcd member from a snapshot.
+func (kmc *Cluster) GetEtcdRestoreJobName(member int32) string {
+	return kmc.getObjectName(fmt.Sprintf("kmc-%%s-etcd-restore-%d", member))
+}
+
 // GetAdminConfigSecretName returns the name of the secret containing the admin kubeconfig
 // for the workload cluster.
 func (kmc *Cluster) GetAdminConfigSecretName() string {
@@ -750,6 +1208,17 @@ func (kmc *Cluster) GetMonitoringNginxConfigMapName() string {
 	return kmc.getObjectName("kmc-prometheus-%s-config-nginx")
 }
 
+// GetAutoscalerName returns the name of the HorizontalPodAutoscaler scaling the k0s control plane.
+func (kmc *Cluster) GetAutoscalerName() string {
+	return kmc.getObjectName("kmc-%s")
+}
+
+// GetAuthenticationConfigSecretName returns the name of the secret containing the kube-apiserver
+// AuthenticationConfiguration.
+func (kmc *Cluster) GetAuthenticationConfigSecretName() string {
+	return kmc.getObjectName("kmc-%s-authentication-config")
+}
+
 // GetConfigMapName returns the name of the configmap containing the k0s configuration for the cluster.
 func (kmc *Cluster) GetConfigMapName() string {
 	return kmc.getObjectName("kmc-%s-config")
@@ -804,6 +1273,16 @@ func (kmc *Cluster) GetIngressName() string {
 	return kmc.getObjectName("kmc-%s")
 }
 
+// GetAPITLSRouteName returns the name of the TLSRoute of the Kubernetes API host
+func (kmc *Cluster) GetAPITLSRouteName() string {
+	return kmc.getObjectName("kmc-%s-api")
+}
+
+// GetKonnectivityTLSRouteName returns the name of the TLSRoute of the konnectivity server host
+func (kmc *Cluster) GetKonnectivityTLSRouteName() string {
+	return kmc.getObjectName("kmc-%s-konnectivity")
+}
+
 // GetIngressManifestsConfigMapName returns the name of the configmap containing the manifests needed for the ingress
 func (kmc *Cluster) GetIngressManifestsConfigMapName() string {
 	return kmc.getObjectName("kmc-%s-ingress")
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestClusterSpec_GetImage(t *testing.T) {
@@ -111,3 +112,25 @@ func TestShortName(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrateServiceAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"keep":                                 "me",
+		ServiceAnnotationLabels:                `{"foo":"bar"}`,
+		ServiceAnnotationAnnotations:           `{"service":"annotation"}`,
+		ServiceAnnotationExternalTrafficPolicy: `"Local"`,
+		ServiceAnnotationLoadBalancerClass:     `"class1"`,
+	}
+	service := ServiceSpec{Annotations: map[string]string{"already": "set"}}
+
+	require.True(t, MigrateServiceAnnotations(annotations, &service))
+	require.Equal(t, map[string]string{"keep": "me"}, annotations)
+	require.Equal(t, ServiceSpec{
+		Labels:                map[string]string{"foo": "bar"},
+		Annotations:           map[string]string{"already": "set"},
+		ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+		LoadBalancerClass:     new("class1"),
+	}, service)
+
+	require.False(t, MigrateServiceAnnotations(annotations, &service))
+}
@@ -18,9 +18,12 @@ package v1beta2
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
+	"github.com/k0sproject/version"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -82,6 +85,7 @@ func (c ClusterValidator) ValidateClusterSpecUpdate(oldKCS, kcs *ClusterSpec) (w
 // ValidateClusterSpec validates the ClusterSpec and returns any warnings or errors.
 func (c ClusterValidator) ValidateClusterSpec(kcs *ClusterSpec) (warnings admission.Warnings, err error) {
 	warnings = c.validateVersionSuffix(kcs.Version)
+	warnings = append(warnings, c.validateSuspended(kcs)...)
 
 	if kcs.Ingress != nil {
 		warn, err := kcs.Ingress.Validate(kcs.Version)
@@ -95,13 +99,153 @@ func (c ClusterValidator) ValidateClusterSpec(kcs *ClusterSpec) (warnings admiss
 		}
 	}
 
+	if kcs.Gateway != nil {
+		if kcs.Ingress != nil {
+			return warnings, fmt.Errorf("ingress and gateway are mutually exclusive")
+		}
+		if err := kcs.Gateway.Validate(kcs.Version); err != nil {
+			return warnings, err
+		}
+	}
+
 	if err := c.validatePatches(kcs.Patches); err != nil {
 		return warnings, err
 	}
 
+	if err := c.validateEtcdBackup(kcs.Storage); err != nil {
+		return warnings, err
+	}
+
+	if err := c.validateAutoscaling(kcs); err != nil {
+		return warnings, err
+	}
+
+	if err := c.validateAuthentication(kcs); err != nil {
+		return warnings, err
+	}
+
+	if err := c.validateAudit(kcs.Audit); err != nil {
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
+// validateAudit validates the audit spec.
+func (c ClusterValidator) validateAudit(audit *AuditSpec) error {
+	if audit == nil {
+		return nil
+	}
+	if audit.Log == nil && audit.Webhook == nil {
+		return fmt.Errorf("audit requires at least one of the log or webhook backends")
+	}
+	return nil
+}
+
+// structuredAuthenticationMinVersion is the first Kubernetes version supporting the AuthenticationConfiguration file.
+var structuredAuthenticationMinVersion = version.MustParse("v1.30.0")
+
+// validateAuthentication validates the authentication spec.
+func (c ClusterValidator) validateAuthentication(kcs *ClusterSpec) error {
+	if kcs.Authentication == nil || kcs.Authentication.OIDC == nil {
+		return nil
+	}
+	oidc := kcs.Authentication.OIDC
+
+	if err := validateIssuerURL(oidc.IssuerURL); err != nil {
+		return fmt.Errorf("invalid OIDC issuer URL %q: %w", oidc.IssuerURL, err)
+	}
+	if ca := oidc.CertificateAuthority; ca != nil && (ca.SecretRef == nil) == (ca.ConfigMapRef == nil) {
+		return fmt.Errorf("exactly one of secretRef or configMapRef must be set in the OIDC certificateAuthority")
+	}
+
+	clusterVersion := kcs.Version
+	if clusterVersion == "" {
+		clusterVersion = DefaultK0SVersion
+	}
+	v, err := version.NewVersion(clusterVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse k0s version %s: %w", clusterVersion, err)
+	}
+	if v.Core().LessThan(structuredAuthenticationMinVersion.Core()) {
+		return fmt.Errorf("OIDC authentication requires k0s version %s or newer, got %s", structuredAuthenticationMinVersion, clusterVersion)
+	}
+
+	// The kube-apiserver refuses to start if both the AuthenticationConfiguration file and the legacy OIDC flags are set.
+	if kcs.K0sConfig != nil {
+		extraArgs, _, _ := unstructured.NestedStringMap(kcs.K0sConfig.Object, "spec", "api", "extraArgs")
+		for arg := range extraArgs {
+			if strings.HasPrefix(arg, "oidc-") || arg == "authentication-config" {
+				return fmt.Errorf("spec.api.extraArgs.%s in k0sConfig can not be used together with OIDC authentication", arg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateIssuerURL checks the issuer URL the same way the kube-apiserver does.
+func validateIssuerURL(issuerURL string) error {
+	u, err := url.Parse(issuerURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("must use the https scheme")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("must have a host")
+	}
+	if u.User != nil {
+		return fmt.Errorf("must not contain a username or password")
+	}
+	if u.RawQuery != "" {
+		return fmt.Errorf("must not contain a query")
+	}
+	if u.Fragment != "" {
+		return fmt.Errorf("must not contain a fragment")
+	}
+	return nil
+}
+
+// validateAutoscaling validates the autoscaling spec.
+func (c ClusterValidator) validateAutoscaling(kcs *ClusterSpec) error {
+	as := kcs.Autoscaling
+	if as == nil {
+		return nil
+	}
+	if kcs.RemoteHostCluster != nil {
+		return fmt.Errorf("autoscaling is not supported when the control plane runs in a remote host cluster")
+	}
+	if kcs.Storage.Type == StorageTypeNATS {
+		return fmt.Errorf("autoscaling is not supported with the NATS storage type")
+	}
+	if as.MinReplicas > as.MaxReplicas {
+		return fmt.Errorf("autoscaling minReplicas (%d) must not be greater than maxReplicas (%d)", as.MinReplicas, as.MaxReplicas)
+	}
+	if as.TargetCPUUtilizationPercentage == nil && as.TargetAPIServerRequestRate == nil {
+		return fmt.Errorf("autoscaling requires at least one of targetCPUUtilizationPercentage or targetAPIServerRequestRate")
+	}
+	if as.TargetCPUUtilizationPercentage != nil && kcs.Resources.Requests.Cpu().IsZero() {
+		return fmt.Errorf("autoscaling on CPU utilization requires resources.requests.cpu to be set")
+	}
+	return nil
+}
+
+// validateEtcdBackup validates the etcd backup spec.
+func (c ClusterValidator) validateEtcdBackup(storage StorageSpec) error {
+	if !storage.Etcd.Backup.Enabled {
+		return nil
+	}
+	if storage.Type != StorageTypeEtcd {
+		return fmt.Errorf("etcd backup is only supported with the etcd storage type")
+	}
+	if err := storage.Etcd.Backup.Destination.Validate(); err != nil {
+		return fmt.Errorf("invalid etcd backup destination: %w", err)
+	}
+	return nil
+}
+
 // validatePatches validates the Patches spec.
 func (c ClusterValidator) validatePatches(patches []ComponentPatch) error {
 	for i, p := range patches {
@@ -115,6 +259,22 @@ func (c ClusterValidator) validatePatches(patches []ComponentPatch) error {
 	return nil
 }
 
+// validateSuspended returns a warning if suspending the cluster loses its data, i.e. the k0s data directory holds the
+// cluster state and is not persisted.
+func (c ClusterValidator) validateSuspended(kcs *ClusterSpec) admission.Warnings {
+	if !kcs.Suspended || kcs.Storage.Type == StorageTypeEtcd {
+		return nil
+	}
+	if kcs.Storage.Type == StorageTypeKine && (kcs.Storage.Kine.DataSourceURL != "" || kcs.Storage.Kine.DataSourceSecretName != "") {
+		return nil
+	}
+	if kcs.Persistence.Type != "" && kcs.Persistence.Type != "emptyDir" {
+		return nil
+	}
+
+	return admission.Warnings{"the cluster data is stored in an emptyDir volume and will be lost when the cluster is suspended"}
+}
+
 // validateVersionSuffix checks if the version has a k0s suffix and returns a warning if it doesn't
 func (c ClusterValidator) validateVersionSuffix(version string) admission.Warnings {
 	warnings := admission.Warnings{}
@@ -151,6 +311,8 @@ func (c *ClusterDefaulter) Default(_ context.Context, obj runtime.Object) error
 		kmc.Spec.Storage.Etcd.Image = DefaultEtcdImage
 	}
 
+	MigrateServiceAnnotations(kmc.Annotations, &kmc.Spec.Service)
+
 	if kmc.Spec.Ingress != nil {
 		if kmc.Spec.Ingress.Deploy == nil {
 			kmc.Spec.Ingress.Deploy = new(true)
@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
@@ -63,6 +64,7 @@ import (
 	"github.com/k0sproject/k0smotron/v2/internal/controller/controlplane"
 	"github.com/k0sproject/k0smotron/v2/internal/controller/infrastructure"
 	controller "github.com/k0sproject/k0smotron/v2/internal/controller/k0smotron.io"
+	kcontrollerutil "github.com/k0sproject/k0smotron/v2/internal/controller/util"
 	"github.com/k0sproject/k0smotron/v2/internal/featuregate"
 	//+kubebuilder:scaffold:imports
 )
@@ -75,8 +77,9 @@ var (
 		controlPlaneController:   true,
 		infrastructureController: true,
 	}
-	featureGates   string
-	managerOptions = flags.ManagerOptions{}
+	featureGates                   string
+	certificateExpiryWarningWindow time.Duration
+	managerOptions                 = flags.ManagerOptions{}
 )
 
 const (
@@ -136,6 +139,8 @@ func main() {
 		"[Deprecated] If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	pflag.CommandLine.StringVar(&featureGates, "feature-gates", "", "feature gates to enable (comma separated list of key=value pairs)")
 	pflag.CommandLine.IntVar(&concurrency, "concurrency", 5, "controller concurrency, default: 5")
+	pflag.CommandLine.DurationVar(&certificateExpiryWarningWindow, "certificate-expiry-warning-window", kcontrollerutil.DefaultCertificateExpiryWarningWindow,
+		"How long before a certificate expires the CertificatesExpiringSoon condition is raised.")
 
 	pflag.CommandLine.StringVar(&enabledController, "enable-controller", "", "The controller to enable. Default: all")
 	pflag.CommandLine.StringVar(&watchFilter, "watch-filter", "", "Label value used to filter reconciled objects via label "+clusterv1.WatchLabel+"=<value>. Enables running multiple provider instances in the same cluster.")
@@ -351,6 +356,8 @@ func main() {
 				ClusterCache:        clusterCache,
 				ClientSet:           clientSet,
 				RESTConfig:          restConfig,
+
+				CertificateExpiryWarningWindow: certificateExpiryWarningWindow,
 			}).SetupWithManager(mgr, ctrlOptions); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "K0sController")
 				os.Exit(1)
@@ -361,6 +368,11 @@ func main() {
 				os.Exit(1)
 			}
 
+			if err = cpv1beta2.SetupK0sControlPlaneTemplateWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create validation webhook", "webhook", "K0sControlPlaneTemplateValidator")
+				os.Exit(1)
+			}
+
 			if err = (&cpv1beta2.K0smotronControlPlaneValidator{}).SetupK0smotronControlPlaneWebhookWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create validation webhook", "webhook", "K0smotronControlPlaneValidator")
 				os.Exit(1)
@@ -391,6 +403,14 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&infrastructure.PooledRemoteMachineController{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr, ctrlOptions); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PooledRemoteMachine")
+			os.Exit(1)
+		}
+
 		if err = infrastructurev1beta2.SetupRemoteMachineWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook for RemoteMachine", "webhook", "RemoteMachine")
 			os.Exit(1)
@@ -473,6 +493,8 @@ func setStandaloneControllers(mgr manager.Manager, clientSet *kubernetes.Clients
 		ClientSet:  clientSet,
 		RESTConfig: restConfig,
 		Recorder:   mgr.GetEventRecorderFor("cluster-reconciler"),
+
+		CertificateExpiryWarningWindow: certificateExpiryWarningWindow,
 	}).SetupWithManager(mgr, opts); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "K0smotronCluster")
 		os.Exit(1)
@@ -482,14 +504,26 @@ func setStandaloneControllers(mgr manager.Manager, clientSet *kubernetes.Clients
 		setupLog.Error(err, "unable to create webhook", "webhook", "k0smotron.Cluster")
 		os.Exit(1)
 	}
+	if err := k0smotronv1beta2.SetupJoinTokenRequestWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "k0smotron.JoinTokenRequest")
+		os.Exit(1)
+	}
 
 	if err := (&controller.JoinTokenRequestReconciler{
 		Client:     mgr.GetClient(),
 		Scheme:     mgr.GetScheme(),
 		ClientSet:  clientSet,
 		RESTConfig: restConfig,
+		Recorder:   mgr.GetEventRecorderFor("jointokenrequest-reconciler"),
 	}).SetupWithManager(mgr, opts); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "JoinTokenRequest")
 		os.Exit(1)
 	}
+	if err := (&controller.EtcdRestoreReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr, opts); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EtcdRestore")
+		os.Exit(1)
+	}
 }
@@ -25,7 +25,6 @@ import (
 	"net/netip"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -687,15 +686,15 @@ func (c *ControlPlaneController) detectJoinHost(ctx context.Context, scope *Cont
 		Timeout:   time.Second,
 	}
 
-	port := "9443"
+	port := int64(9443)
 	k0sAPIPort, found, err := unstructured.NestedInt64(scope.Config.Spec.K0sConfigSpec.K0s.Object, "spec", "api", "k0sApiPort")
 	if err != nil {
 		return "", fmt.Errorf("error retrieving k0sAPIPort: %w", err)
 	}
 	if found && k0sAPIPort > 0 {
-		port = strconv.Itoa(int(k0sAPIPort))
+		port = k0sAPIPort
 	}
-	host := fmt.Sprintf("https://%s:%s", scope.Cluster.Spec.ControlPlaneEndpoint.Host, port)
+	host := util.ServerURL(scope.Cluster.Spec.ControlPlaneEndpoint.Host, port)
 
 	_, err = httpClient.Get(fmt.Sprintf("%s/v1beta1/ca", host))
 	if err == nil {
@@ -707,7 +706,7 @@ func (c *ControlPlaneController) detectJoinHost(ctx context.Context, scope *Cont
 		return "", fmt.Errorf("failed to get first controller IP: %w", err)
 	}
 
-	return fmt.Sprintf("https://%s:%s", firstControllerIP, port), nil
+	return util.ServerURL(firstControllerIP, port), nil
 }
 
 func (c *ControlPlaneController) findFirstControllerIP(ctx context.Context, firstControllerMachine *clusterv1.Machine) (string, error) {
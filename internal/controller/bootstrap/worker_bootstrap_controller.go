@@ -529,9 +529,9 @@ func (r *Controller) getK0sToken(ctx context.Context, scope *Scope) (string, err
 	}
 
 	var joinToken string
-	joinURL := fmt.Sprintf("https://%s:%d", scope.Cluster.Spec.ControlPlaneEndpoint.Host, scope.Cluster.Spec.ControlPlaneEndpoint.Port)
+	joinURL := util.ServerURL(scope.Cluster.Spec.ControlPlaneEndpoint.Host, int64(scope.Cluster.Spec.ControlPlaneEndpoint.Port))
 	if scope.ingressSpec != nil {
-		joinURL = fmt.Sprintf("https://%s:%d", scope.ingressSpec.APIHost, scope.ingressSpec.Port)
+		joinURL = util.ServerURL(scope.ingressSpec.APIHost, scope.ingressSpec.Port)
 	}
 
 	joinToken, err := kutil.CreateK0sJoinToken(ca.KeyPair.Cert, token, joinURL, "kubelet-bootstrap")
@@ -669,7 +669,7 @@ func (r *Controller) setClientScope(ctx context.Context, cluster *clusterv1.Clus
 			return err
 		}
 
-		scope.ingressSpec = kcp.Spec.Ingress
+		scope.ingressSpec = kcp.Spec.GetIngressEndpoint()
 
 		if kcp.Spec.RemoteHostCluster != nil && kcp.Spec.RemoteHostCluster.KubeconfigRef != nil {
 			var err error
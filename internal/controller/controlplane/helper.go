@@ -35,6 +35,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -47,6 +48,7 @@ import (
 	bootstrapv2 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
 	cpv1beta2 "github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
 	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+	kutil "github.com/k0sproject/k0smotron/v2/internal/util"
 )
 
 const (
@@ -109,6 +111,14 @@ func (c *K0sController) generateMachine(_ context.Context, name string, cluster
 	}
 	annotations[cpv1beta2.MachineK0sConfigAnnotation] = k0sConfigAnnotationValue
 
+	// If the machine replaces a remediated machine, keep track of the remediation so that the retry limits of the
+	// remediation strategy can be enforced if this machine also fails.
+	if remediationValue, ok := kcp.Annotations[cpv1beta2.RemediationInProgressAnnotation]; ok {
+		if _, err := remediationDataFromAnnotation(remediationValue); err == nil {
+			annotations[cpv1beta2.RemediationForAnnotation] = remediationValue
+		}
+	}
+
 	machine := &clusterv1.Machine{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: clusterv1.GroupVersion.String(),
@@ -139,6 +149,16 @@ func (c *K0sController) generateMachine(_ context.Context, name string, cluster
 	return machine, nil
 }
 
+// generateMachineName returns the name for a new control plane machine. The name is also used for the bootstrap
+// config and the infrastructure machine.
+func generateMachineName(kcp *cpv1beta2.K0sControlPlane, clusterName string) (string, error) {
+	if kcp.Spec.MachineNamingStrategy == nil || kcp.Spec.MachineNamingStrategy.Template == "" {
+		return names.SimpleNameGenerator.GenerateName(fmt.Sprintf("%s-", kcp.Name)), nil
+	}
+
+	return kutil.ControlPlaneMachineName(kcp.Spec.MachineNamingStrategy.Template, clusterName, kcp.Name)
+}
+
 func generateK0sConfigAnnotationValueForMachine(kcp *cpv1beta2.K0sControlPlane, machineName string) (string, error) {
 	// We make a copy of the K0sControlPlane to avoid modifying the original object with a value that is specific to a machine.
 	kcpCopy := kcp.DeepCopy()
@@ -278,6 +298,11 @@ func isBootstrapConfigUpToDate(bootstrapConfig *bootstrapv2.K0sControllerConfig,
 		return false
 	}
 
+	// Machines created before rolloutAfter must be replaced regardless of their configuration.
+	if kcp.IsRolloutDue(machine, time.Now()) {
+		return false
+	}
+
 	if bootstrapConfig == nil {
 		// In cases where the bootstrap config is not found, follow more conservative approach and consider it up to date.
 		return true
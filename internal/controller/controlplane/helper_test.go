@@ -21,6 +21,7 @@ package controlplane
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	bootstrapv1 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta1"
 	bootstrapv2 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
@@ -436,3 +437,74 @@ func TestHasControllerConfigChanged_LegacyAnnotationNoRollout(t *testing.T) {
 	// bootstrapConfigs is only used by the deprecated fallback path; the annotation path is taken here.
 	require.True(t, isBootstrapConfigUpToDate(convertedLegacyConfig, kcp, machine))
 }
+
+func TestGenerateMachineName(t *testing.T) {
+	kcp := &cpv1beta2.K0sControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "test-kcp"}}
+
+	name, err := generateMachineName(kcp, "test-cluster")
+	require.NoError(t, err)
+	require.Regexp(t, `^test-kcp-[a-z0-9]{5}$`, name)
+
+	kcp.Spec.MachineNamingStrategy = &cpv1beta2.MachineNamingStrategy{Template: "{{ .cluster.name }}-cp-{{ .random }}"}
+	name, err = generateMachineName(kcp, "test-cluster")
+	require.NoError(t, err)
+	require.Regexp(t, `^test-cluster-cp-[a-z0-9]{5}$`, name)
+
+	kcp.Spec.MachineNamingStrategy = &cpv1beta2.MachineNamingStrategy{Template: "{{ .k0sControlPlane.name }}-{{ .cluster.name }}-with-a-suffix-that-does-not-fit-in-the-name-{{ .random }}"}
+	name, err = generateMachineName(kcp, "test-cluster")
+	require.NoError(t, err)
+	require.Len(t, name, 63)
+	require.Equal(t, "test-kcp-test-cluster-with-a-suffix-that-does-not-fit-in-t", name[:58])
+
+	kcp.Spec.MachineNamingStrategy = &cpv1beta2.MachineNamingStrategy{Template: "{{ .unknown.name }}-{{ .random }}"}
+	_, err = generateMachineName(kcp, "test-cluster")
+	require.Error(t, err)
+}
+
+func TestIsBootstrapConfigUpToDate_RolloutAfter(t *testing.T) {
+	now := time.Now()
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		rolloutAfter *metav1.Time
+		want         bool
+	}{
+		{
+			name: "no rolloutAfter",
+			want: true,
+		},
+		{
+			name:         "rolloutAfter in the future",
+			rolloutAfter: new(metav1.NewTime(now.Add(time.Hour))),
+			want:         true,
+		},
+		{
+			name:         "machine created after rolloutAfter",
+			rolloutAfter: new(metav1.NewTime(now.Add(-2 * time.Hour))),
+			want:         true,
+		},
+		{
+			name:         "machine created before rolloutAfter",
+			rolloutAfter: new(metav1.NewTime(now.Add(-time.Minute))),
+			want:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kcp := &cpv1beta2.K0sControlPlane{
+				Spec: cpv1beta2.K0sControlPlaneSpec{
+					Rollout: cpv1beta2.K0sControlPlaneRolloutSpec{RolloutAfter: tc.rolloutAfter},
+				},
+			}
+			// A missing bootstrap config is considered up to date, so only rolloutAfter decides.
+			require.Equal(t, tc.want, isBootstrapConfigUpToDate(nil, kcp, machine))
+		})
+	}
+}
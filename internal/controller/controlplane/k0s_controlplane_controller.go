@@ -59,6 +59,7 @@ import (
 
 	bootstrapv2 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
 	cpv1beta2 "github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/metrics"
 	kutil "github.com/k0sproject/k0smotron/v2/internal/util"
 )
 
@@ -114,6 +115,9 @@ type K0sController struct {
 	RESTConfig          *rest.Config
 	// workloadClusterKubeClient is used during testing to inject a fake client
 	workloadClusterKubeClient *kubernetes.Clientset
+	// CertificateExpiryWarningWindow is the time before expiry after which certificates are reported as expiring soon.
+	// Defaults to 30 days.
+	CertificateExpiryWarningWindow time.Duration
 	// autopilotUpdateCancels holds the cancel function for the running updateMachineVersions
 	// goroutine of each control plane, keyed by its NamespacedName.
 	autopilotUpdateCancels sync.Map
@@ -341,7 +345,7 @@ func (c *K0sController) reconcileKubeconfig(ctx context.Context, controlplane *c
 			err := c.SecretCachingClient.Get(ctx, client.ObjectKey{Namespace: controlplane.cluster.Namespace, Name: secretName}, tunneledKubeconfig)
 			if err != nil {
 				if apierrors.IsNotFound(err) {
-					kc, err := c.generateKubeconfig(ctx, clusterKey, fmt.Sprintf("https://%s:%d", controlplane.kcp.Spec.K0sConfigSpec.Tunneling.ServerAddress, controlplane.kcp.Spec.K0sConfigSpec.Tunneling.TunnelingNodePort))
+					kc, err := c.generateKubeconfig(ctx, clusterKey, util.ServerURL(controlplane.kcp.Spec.K0sConfigSpec.Tunneling.ServerAddress, int64(controlplane.kcp.Spec.K0sConfigSpec.Tunneling.TunnelingNodePort)))
 					if err != nil {
 						return err
 					}
@@ -424,7 +428,13 @@ func (c *K0sController) ensureCertificates(ctx context.Context, controlplane *co
 	certificates := secret.NewCertificatesForInitialControlPlane(&kubeadmbootstrapv1.ClusterConfiguration{
 		CertificatesDir: "/var/lib/k0s/pki",
 	})
-	return certificates.LookupOrGenerateCached(ctx, c.SecretCachingClient, c.Client, capiutil.ObjectKey(controlplane.cluster), *metav1.NewControllerRef(controlplane.kcp, cpv1beta2.GroupVersion.WithKind("K0sControlPlane")))
+	err := certificates.LookupOrGenerateCached(ctx, c.SecretCachingClient, c.Client, capiutil.ObjectKey(controlplane.cluster), *metav1.NewControllerRef(controlplane.kcp, cpv1beta2.GroupVersion.WithKind("K0sControlPlane")))
+	if err != nil {
+		return err
+	}
+
+	controlplane.kcp.Status.Certificates = util.CertificateStatuses(controlplane.cluster.Name, certificates)
+	return nil
 }
 
 func (c *K0sController) reconcileConfig(ctx context.Context, controlplane *controlplane) error {
@@ -495,7 +505,7 @@ func (c *K0sController) reconcileTunneling(ctx context.Context, controlplane *co
 	}
 
 	if controlplane.kcp.Spec.K0sConfigSpec.Tunneling.ServerAddress == "" {
-		ip, err := util.FindNodeAddress(ctx, c.Client)
+		ip, err := util.FindNodeAddress(ctx, c.Client, "")
 		if err != nil {
 			return fmt.Errorf("error detecting node IP: %w", err)
 		}
@@ -681,6 +691,7 @@ func (c *K0sController) reconcileDelete(ctx context.Context, controlplane *contr
 
 	if len(cpMachines) == 0 {
 		// No machines left, we can finally delete the K0sControlPlane by removing the finalizer.
+		metrics.DeleteCertificateExpiry("K0sControlPlane", controlplane.kcp.Namespace, controlplane.kcp.Name)
 		controllerutil.RemoveFinalizer(controlplane.kcp, cpv1beta2.K0sControlPlaneFinalizer)
 		return ctrl.Result{}, nil
 	}
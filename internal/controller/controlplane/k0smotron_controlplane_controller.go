@@ -165,6 +165,10 @@ func (c *K0smotronController) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	if kapi.MigrateServiceAnnotations(kcp.Annotations, &kcp.Spec.Service) {
+		log.Info("Migrated deprecated service annotations into spec.service")
+	}
+
 	kmcScope, err := c.getKmcScope(ctx, kcp)
 	if err != nil {
 		log.Error(err, "Error getting kmc scope")
@@ -267,9 +271,9 @@ func (c *K0smotronController) ensureExternalAddress(ctx context.Context, kcp *cp
 			host string
 			port int
 		)
-		if kcp.Spec.Ingress != nil {
-			host = k0smoCluster.Spec.Ingress.APIHost
-			port = int(k0smoCluster.Spec.Ingress.Port)
+		if ingress := k0smoCluster.Spec.GetIngressEndpoint(); ingress != nil {
+			host = ingress.APIHost
+			port = int(ingress.Port)
 			if port == 0 {
 				port = 443
 			}
@@ -412,8 +416,13 @@ func (c *K0smotronController) reconcile(ctx context.Context, cluster *clusterv1.
 		}
 		foundCluster.Annotations[AnnotationKeyClusterSpecHash] = kcpSpecHash
 
-		// Modidy current Cluster specification with the desired one.
+		// Modidy current Cluster specification with the desired one. When autoscaling is enabled, the replicas are
+		// managed by the HorizontalPodAutoscaler and must be kept as they are.
+		replicas := foundCluster.Spec.Replicas
 		foundCluster.Spec = kcp.Spec
+		if kcp.Spec.Autoscaling != nil {
+			foundCluster.Spec.Replicas = replicas
+		}
 
 		return ctrl.Result{}, patchHelper.Patch(ctx, &foundCluster)
 	}
@@ -457,6 +466,10 @@ func isClusterSpecSynced(kmc kapi.Cluster, kcpSpec kapi.ClusterSpec) (isSynced b
 	// K0smotron Cluster controller can add additional certificate references, such as 'apiserver-etcd-client'. Therefore, we explicitly reset the
 	// certificate references based on the original Cluster definition.
 	overridenKmcSpec.CertificateRefs = kmcSpec.CertificateRefs
+	// Replicas are managed by the HorizontalPodAutoscaler when autoscaling is enabled.
+	if kcpSpec.Autoscaling != nil {
+		overridenKmcSpec.Replicas = kmcSpec.Replicas
+	}
 
 	// K0smotron Cluster controller will add additional a manifest for the endpoint configmap. Therefore, we explicitly add the manifest to avoid differences.
 	isEndpointConfigMapInManifests := false
@@ -599,6 +612,21 @@ func (c *K0smotronController) computeStatus(ctx context.Context, cluster *cluste
 		kcp.Status.Version = minimumVersion.String()
 	}
 
+	if suspended := conditions.Get(&kmc, kapi.ClusterSuspendedCondition); suspended != nil {
+		conditions.Set(kcp, *suspended)
+	}
+
+	// A suspended control plane keeps its initialized status, it is only reported as unavailable until it is resumed.
+	if kcp.Spec.Suspended {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    string(cpv1beta2.ControlPlaneAvailableCondition),
+			Status:  metav1.ConditionFalse,
+			Reason:  kapi.ClusterSuspendedReason,
+			Message: "Control plane is suspended",
+		})
+		return nil
+	}
+
 	c.computeAvailability(ctx, cluster, kcp)
 
 	// if no replicas are yet available or the desired version is not in the current state of the
@@ -867,6 +895,11 @@ func (c *K0smotronController) getKmcScope(ctx context.Context, kcp *cpv1beta2.K0
 func generateClusterSpecHashWithoutExternalAddress(spec kapi.ClusterSpec) string {
 	normalizedSpec := spec.DeepCopy()
 	normalizedSpec.ExternalAddress = ""
+	// Replicas are managed by the HorizontalPodAutoscaler when autoscaling is enabled, so changes to them
+	// must not be considered as a spec change.
+	if normalizedSpec.Autoscaling != nil {
+		normalizedSpec.Replicas = 0
+	}
 	data, _ := json.Marshal(normalizedSpec)
 	hasher := fnv.New32a()
 	_, _ = hasher.Write(data)
@@ -334,6 +334,50 @@ func TestIsClusterSpecSynced(t *testing.T) {
 
 }
 
+func TestIsClusterSpecSynced_Autoscaling(t *testing.T) {
+	kcpSpec := kapi.ClusterSpec{
+		Replicas: 1,
+		Autoscaling: &kapi.AutoscalingSpec{
+			MinReplicas:                    1,
+			MaxReplicas:                    5,
+			TargetCPUUtilizationPercentage: new(int32(80)),
+		},
+	}
+
+	kmc := kapi.Cluster{ObjectMeta: v1.ObjectMeta{Name: "test"}}
+	// Simulate the HorizontalPodAutoscaler scaling the cluster up and the manifest volume created by the controller.
+	kmc.Spec = *kcpSpec.DeepCopy()
+	kmc.Spec.Replicas = 3
+	kmc.Spec.Manifests = []corev1.Volume{
+		{
+			Name: kmc.GetEndpointConfigMapName(),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: kmc.GetEndpointConfigMapName(),
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("without annotation", func(t *testing.T) {
+		synced, _, err := isClusterSpecSynced(kmc, kcpSpec)
+		require.NoError(t, err)
+		require.True(t, synced)
+	})
+
+	t.Run("with annotation", func(t *testing.T) {
+		kmc.Annotations = map[string]string{AnnotationKeyClusterSpecHash: generateClusterSpecHashWithoutExternalAddress(kcpSpec)}
+
+		// Changing the replicas in the K0smotronControlPlane has no effect when autoscaling is enabled.
+		kcpSpec.Replicas = 2
+		synced, _, err := isClusterSpecSynced(kmc, kcpSpec)
+		require.NoError(t, err)
+		require.True(t, synced)
+	})
+}
+
 func Test_alignToSpecVersionFormat(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -18,7 +18,11 @@ package controlplane
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
 	"k8s.io/utils/ptr"
 
 	cpv1beta2 "github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
@@ -77,6 +81,16 @@ func (c *K0sController) reconcileUnhealthyMachines(ctx context.Context, scope *c
 			return
 		}
 	}()
+
+	remediationInProgress, canRemediate, err := checkRetryLimits(log, machineToBeRemediated, scope.kcp, time.Now())
+	if err != nil {
+		return err
+	}
+	if !canRemediate {
+		// NOTE: log lines and conditions surfacing why it is not possible to remediate are set by checkRetryLimits.
+		return nil
+	}
+
 	// Ensure that the cluster remains available during and after the remediation process. The remediation must not
 	// compromise the cluster's ability to serve workloads or cause disruption to the control plane's functionality.
 	if ptr.Deref(scope.kcp.Status.Initialization.ControlPlaneInitialized, false) {
@@ -131,14 +145,128 @@ func (c *K0sController) reconcileUnhealthyMachines(ctx context.Context, scope *c
 	log.Info("Remediated unhealthy machine, another new machine should take its place soon.")
 
 	// Mark controlplane to track that remediation is in progress and do not proceed until machine is gone.
-	// This annotation is removed when new controlplane creates a new machine.
+	// This annotation is moved to the replacement machine as RemediationForAnnotation when the new machine is created.
+	remediationInProgressValue, err := remediationInProgress.marshal()
+	if err != nil {
+		return err
+	}
 	annotations.AddAnnotations(scope.kcp, map[string]string{
-		cpv1beta2.RemediationInProgressAnnotation: "true",
+		cpv1beta2.RemediationInProgressAnnotation: remediationInProgressValue,
 	})
+	scope.kcp.Status.LastRemediation = remediationInProgress.toStatus()
 
 	return nil
 }
 
+// remediationData tracks a remediation in the RemediationInProgressAnnotation on the K0sControlPlane and in the
+// RemediationForAnnotation on the replacement Machine.
+type remediationData struct {
+	// Machine is the name of the Machine that was remediated.
+	Machine string `json:"machine"`
+	// Timestamp is the time the remediation started.
+	Timestamp metav1.Time `json:"timestamp"`
+	// RetryCount is the number of times the remediation was retried for the same unhealthy Machine.
+	RetryCount int32 `json:"retryCount"`
+}
+
+func remediationDataFromAnnotation(value string) (*remediationData, error) {
+	data := &remediationData{}
+	if err := json.Unmarshal([]byte(value), data); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal value %q for %s annotation", value, cpv1beta2.RemediationForAnnotation)
+	}
+	return data, nil
+}
+
+func (r *remediationData) marshal() (string, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal remediation data")
+	}
+	return string(b), nil
+}
+
+func (r *remediationData) toStatus() *cpv1beta2.LastRemediationStatus {
+	return &cpv1beta2.LastRemediationStatus{
+		Machine:    r.Machine,
+		Time:       r.Timestamp,
+		RetryCount: r.RetryCount,
+	}
+}
+
+// checkRetryLimits checks if the K0sControlPlane is allowed to remediate the Machine considering the RemediationStrategy:
+// - remediation cannot happen because RetryPeriod is not yet expired.
+// - the maximum number of retries (MaxRetry) for a Machine is already reached.
+// A failure is considered a retry if the Machine was created as a replacement for an unhealthy Machine less than
+// MinHealthyPeriod ago.
+func checkRetryLimits(log logr.Logger, machineToBeRemediated *clusterv1.Machine, kcp *cpv1beta2.K0sControlPlane, reconciliationTime time.Time) (*remediationData, bool, error) {
+	remediationInProgress := &remediationData{
+		Machine:    machineToBeRemediated.Name,
+		Timestamp:  metav1.NewTime(reconciliationTime),
+		RetryCount: 0,
+	}
+
+	value, ok := machineToBeRemediated.Annotations[cpv1beta2.RemediationForAnnotation]
+	if !ok {
+		// This is the first try of a new retry sequence.
+		return remediationInProgress, true, nil
+	}
+	lastRemediation, err := remediationDataFromAnnotation(value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	minHealthyPeriod := cpv1beta2.DefaultMinHealthyPeriod
+	var retryPeriod time.Duration
+	var maxRetry *int32
+	if strategy := kcp.Spec.RemediationStrategy; strategy != nil {
+		if strategy.MinHealthyPeriod != nil {
+			minHealthyPeriod = strategy.MinHealthyPeriod.Duration
+		}
+		retryPeriod = strategy.RetryPeriod.Duration
+		maxRetry = strategy.MaxRetry
+	}
+
+	// If the annotation has no timestamp, assume that both MinHealthyPeriod and RetryPeriod are expired.
+	lastRemediationTime := reconciliationTime.Add(-2 * max(minHealthyPeriod, retryPeriod))
+	if !lastRemediation.Timestamp.IsZero() {
+		lastRemediationTime = lastRemediation.Timestamp.Time
+	}
+
+	// The replacement machine stayed healthy for long enough, so this is the first try of a new retry sequence.
+	if !lastRemediationTime.Add(minHealthyPeriod).After(reconciliationTime) {
+		return remediationInProgress, true, nil
+	}
+
+	log = log.WithValues("remediationRetryFor", lastRemediation.Machine)
+	remediationInProgress.RetryCount = lastRemediation.RetryCount
+
+	if lastRemediationTime.Add(retryPeriod).After(reconciliationTime) {
+		log.Info(fmt.Sprintf("A control plane machine needs remediation, but the operation already failed in the latest %s. Skipping remediation", retryPeriod))
+		conditions.Set(machineToBeRemediated, metav1.Condition{
+			Type:    string(clusterv1.MachineOwnerRemediatedCondition),
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineOwnerRemediatedWaitingForRemediationReason,
+			Message: fmt.Sprintf("KCP can't remediate this machine because the operation already failed in the latest %s (RetryPeriod)", retryPeriod),
+		})
+		return remediationInProgress, false, nil
+	}
+
+	if maxRetry != nil && remediationInProgress.RetryCount >= *maxRetry {
+		log.Info(fmt.Sprintf("A control plane machine needs remediation, but the operation already failed %d times (MaxRetry %d). Skipping remediation", remediationInProgress.RetryCount, *maxRetry))
+		conditions.Set(machineToBeRemediated, metav1.Condition{
+			Type:    string(clusterv1.MachineOwnerRemediatedCondition),
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineOwnerRemediatedWaitingForRemediationReason,
+			Message: fmt.Sprintf("KCP can't remediate this machine because the operation already failed %d times (MaxRetry)", *maxRetry),
+		})
+		return remediationInProgress, false, nil
+	}
+
+	remediationInProgress.RetryCount++
+
+	return remediationInProgress, true, nil
+}
+
 func isHealthy(machine *clusterv1.Machine) bool {
 	if machine == nil {
 		return false
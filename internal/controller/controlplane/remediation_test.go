@@ -0,0 +1,147 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	cpv1beta2 "github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
+)
+
+func TestCheckRetryLimits(t *testing.T) {
+	now := time.Now()
+
+	remediationFor := func(ago time.Duration, retryCount int32) map[string]string {
+		data := &remediationData{Machine: "old-machine", Timestamp: metav1.NewTime(now.Add(-ago)), RetryCount: retryCount}
+		value, err := data.marshal()
+		require.NoError(t, err)
+		return map[string]string{cpv1beta2.RemediationForAnnotation: value}
+	}
+
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		strategy       *cpv1beta2.RemediationStrategy
+		wantRemediate  bool
+		wantRetryCount int32
+	}{
+		{
+			name:           "first remediation",
+			wantRemediate:  true,
+			wantRetryCount: 0,
+		},
+		{
+			name:           "replacement failed within the default min healthy period",
+			annotations:    remediationFor(10*time.Minute, 0),
+			wantRemediate:  true,
+			wantRetryCount: 1,
+		},
+		{
+			name:           "replacement failed after the min healthy period",
+			annotations:    remediationFor(2*time.Hour, 3),
+			wantRemediate:  true,
+			wantRetryCount: 0,
+		},
+		{
+			name:           "custom min healthy period",
+			annotations:    remediationFor(10*time.Minute, 3),
+			strategy:       &cpv1beta2.RemediationStrategy{MinHealthyPeriod: &metav1.Duration{Duration: 5 * time.Minute}},
+			wantRemediate:  true,
+			wantRetryCount: 0,
+		},
+		{
+			name:          "retry period not expired",
+			annotations:   remediationFor(10*time.Minute, 0),
+			strategy:      &cpv1beta2.RemediationStrategy{RetryPeriod: metav1.Duration{Duration: 15 * time.Minute}},
+			wantRemediate: false,
+		},
+		{
+			name:           "retry period expired",
+			annotations:    remediationFor(20*time.Minute, 0),
+			strategy:       &cpv1beta2.RemediationStrategy{RetryPeriod: metav1.Duration{Duration: 15 * time.Minute}},
+			wantRemediate:  true,
+			wantRetryCount: 1,
+		},
+		{
+			name:          "max retry reached",
+			annotations:   remediationFor(10*time.Minute, 2),
+			strategy:      &cpv1beta2.RemediationStrategy{MaxRetry: new(int32(2))},
+			wantRemediate: false,
+		},
+		{
+			name:           "max retry not reached",
+			annotations:    remediationFor(10*time.Minute, 1),
+			strategy:       &cpv1beta2.RemediationStrategy{MaxRetry: new(int32(2))},
+			wantRemediate:  true,
+			wantRetryCount: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine", Annotations: tc.annotations}}
+			kcp := &cpv1beta2.K0sControlPlane{Spec: cpv1beta2.K0sControlPlaneSpec{RemediationStrategy: tc.strategy}}
+
+			data, canRemediate, err := checkRetryLimits(logr.Discard(), machine, kcp, now)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantRemediate, canRemediate)
+			if tc.wantRemediate {
+				require.Equal(t, "machine", data.Machine)
+				require.Equal(t, tc.wantRetryCount, data.RetryCount)
+			} else {
+				require.True(t, conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition))
+			}
+		})
+	}
+}
+
+func TestSetLastRemediation(t *testing.T) {
+	// The annotation is serialized with second precision.
+	now := time.Now().Truncate(time.Second)
+	data := &remediationData{Machine: "old-machine", Timestamp: metav1.NewTime(now.Add(-time.Minute)), RetryCount: 1}
+	value, err := data.marshal()
+	require.NoError(t, err)
+
+	scope := &controlplane{
+		kcp: &cpv1beta2.K0sControlPlane{},
+		activeMachines: map[string]*clusterv1.Machine{
+			"machine": {ObjectMeta: metav1.ObjectMeta{
+				Name:              "machine",
+				CreationTimestamp: metav1.NewTime(now),
+				Annotations:       map[string]string{cpv1beta2.RemediationForAnnotation: value},
+			}},
+		},
+	}
+
+	setLastRemediation(scope)
+	require.Equal(t, data.toStatus(), scope.kcp.Status.LastRemediation)
+
+	// A more recent remediation is not overwritten.
+	newer := &cpv1beta2.LastRemediationStatus{Machine: "machine", Time: metav1.NewTime(now), RetryCount: 2}
+	scope.kcp.Status.LastRemediation = newer
+	setLastRemediation(scope)
+	require.Equal(t, newer, scope.kcp.Status.LastRemediation)
+}
@@ -28,7 +28,6 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -97,6 +96,12 @@ func (c *K0sController) reconcileMachines(ctx context.Context, scope *controlpla
 	}
 
 	logger.Info("Control plane machines are in the desired state")
+
+	// Come back when a scheduled rollout is due.
+	if rolloutAfter := scope.kcp.Spec.Rollout.RolloutAfter; rolloutAfter != nil && rolloutAfter.After(time.Now()) {
+		return ctrl.Result{RequeueAfter: time.Until(rolloutAfter.Time)}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -176,6 +181,16 @@ func isNeededScaleUp(scope *controlplane) bool {
 }
 
 func calculateMaxSurge(scope *controlplane) int {
+	if maxSurge := scope.kcp.Spec.Rollout.Strategy.RollingUpdate.MaxSurge; maxSurge != nil {
+		// Same as for UpdateRecreateDeleteFirst, deleting a machine first is only safe when the remaining
+		// machines keep the etcd quorum. Replicas can be changed through the scale subresource, which
+		// bypasses the webhook, so check it here too.
+		if *maxSurge == 0 && scope.kcp.Spec.Replicas < 3 {
+			return int(scope.kcp.Spec.Replicas) + 1
+		}
+		return int(scope.kcp.Spec.Replicas) + int(*maxSurge)
+	}
+
 	if scope.kcp.Spec.UpdateStrategy == cpv1beta2.UpdateRecreateDeleteFirst && scope.kcp.Spec.Replicas >= 3 {
 		// We cannot exceed desired replicas when UpdateRecreateDeleteFirst strategy is set. Consider this
 		// strategy when spec replicas >= 3.
@@ -188,7 +203,10 @@ func calculateMaxSurge(scope *controlplane) int {
 
 func (c *K0sController) scaleUp(ctx context.Context, scope *controlplane) error {
 	logger := log.FromContext(ctx)
-	newMachineName := names.SimpleNameGenerator.GenerateName(fmt.Sprintf("%s-", scope.kcp.Name))
+	newMachineName, err := generateMachineName(scope.kcp, scope.cluster.Name)
+	if err != nil {
+		return fmt.Errorf("error generating machine name: %w", err)
+	}
 
 	infraMachine, err := c.createMachineFromTemplate(ctx, newMachineName, scope.cluster, scope.kcp)
 	if err != nil {
@@ -320,8 +338,13 @@ func (c *K0sController) isLatestMachineReady(ctx context.Context, scope *control
 	return conditions.IsTrue(latestCreatedMachine, clusterv1.MachineAvailableCondition)
 }
 
+// retrieveOldestMachineOutOfDate returns the oldest machine that is not up to date, along with its infrastructure machine and
+// controller config. Machines created before spec.rollout.rolloutAfter are not up to date, see isBootstrapConfigUpToDate.
 func retrieveOldestMachineOutOfDate(scope *controlplane) (*clusterv1.Machine, *unstructured.Unstructured, *bootstrapv2.K0sControllerConfig, error) {
 	machine := scope.notUpToDateMachines.Oldest()
+	if machine == nil {
+		return nil, nil, nil, errors.New("no machine out of date found")
+	}
 
 	config, ok := scope.controllerConfigs[machine.Name]
 	if !ok {
@@ -0,0 +1,96 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cpv1beta2 "github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
+)
+
+func TestCalculateMaxSurge(t *testing.T) {
+	testCases := []struct {
+		name           string
+		replicas       int32
+		updateStrategy cpv1beta2.UpdateStrategy
+		maxSurge       *int32
+		want           int
+	}{
+		{
+			name:           "default",
+			replicas:       3,
+			updateStrategy: cpv1beta2.UpdateRecreate,
+			want:           4,
+		},
+		{
+			name:           "delete first",
+			replicas:       3,
+			updateStrategy: cpv1beta2.UpdateRecreateDeleteFirst,
+			want:           3,
+		},
+		{
+			name:           "delete first on small cluster",
+			replicas:       1,
+			updateStrategy: cpv1beta2.UpdateRecreateDeleteFirst,
+			want:           2,
+		},
+		{
+			name:           "maxSurge 1 overrides delete first",
+			replicas:       3,
+			updateStrategy: cpv1beta2.UpdateRecreateDeleteFirst,
+			maxSurge:       new(int32(1)),
+			want:           4,
+		},
+		{
+			name:           "maxSurge 0",
+			replicas:       5,
+			updateStrategy: cpv1beta2.UpdateRecreate,
+			maxSurge:       new(int32(0)),
+			want:           5,
+		},
+		{
+			name:           "maxSurge 0 on small cluster",
+			replicas:       1,
+			updateStrategy: cpv1beta2.UpdateRecreate,
+			maxSurge:       new(int32(0)),
+			want:           2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &controlplane{
+				kcp: &cpv1beta2.K0sControlPlane{
+					Spec: cpv1beta2.K0sControlPlaneSpec{
+						Replicas:       tc.replicas,
+						UpdateStrategy: tc.updateStrategy,
+						Rollout: cpv1beta2.K0sControlPlaneRolloutSpec{
+							Strategy: cpv1beta2.K0sControlPlaneRolloutStrategy{
+								RollingUpdate: cpv1beta2.K0sControlPlaneRollingUpdate{MaxSurge: tc.maxSurge},
+							},
+						},
+					},
+				},
+			}
+			require.Equal(t, tc.want, calculateMaxSurge(scope))
+		})
+	}
+}
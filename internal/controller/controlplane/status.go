@@ -36,6 +36,7 @@ import (
 
 	"github.com/go-logr/logr"
 	cpv1beta2 "github.com/k0sproject/k0smotron/v2/api/controlplane/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/metrics"
 	"github.com/k0sproject/version"
 )
 
@@ -55,9 +56,40 @@ func (c *K0sController) updateStatus(ctx context.Context, controlplane *controlp
 
 	controlplane.kcp.Status.Selector = collections.ControlPlaneSelectorForCluster(controlplane.cluster.Name).String()
 
+	setLastRemediation(controlplane)
+	kutil.SetCertificatesExpiringSoonCondition(controlplane.kcp, controlplane.kcp.Status.Certificates, c.CertificateExpiryWarningWindow, time.Now())
+	metrics.SetCertificateExpiry("K0sControlPlane", controlplane.kcp.Namespace, controlplane.kcp.Name, controlplane.kcp.Status.Certificates)
+
 	return computeReplicas(controlplane)
 }
 
+// setLastRemediation surfaces the remediation tracked on the newest replacement machine. If there is no such machine,
+// e.g. because it was deleted, the last known value is preserved.
+func setLastRemediation(controlplane *controlplane) {
+	var newest *clusterv1.Machine
+	for _, m := range controlplane.activeMachines {
+		if _, ok := m.Annotations[cpv1beta2.RemediationForAnnotation]; !ok {
+			continue
+		}
+		if newest == nil || newest.CreationTimestamp.Before(&m.CreationTimestamp) {
+			newest = m
+		}
+	}
+	if newest == nil {
+		return
+	}
+
+	data, err := remediationDataFromAnnotation(newest.Annotations[cpv1beta2.RemediationForAnnotation])
+	if err != nil {
+		return
+	}
+	// A remediation started after the replacement machine was created is already more recent.
+	if last := controlplane.kcp.Status.LastRemediation; last != nil && data.Timestamp.Before(&last.Time) {
+		return
+	}
+	controlplane.kcp.Status.LastRemediation = data.toStatus()
+}
+
 func computeReplicas(controlplane *controlplane) error {
 	controlplane.kcp.Status.Replicas = new(int32(len(controlplane.activeMachines)))
 
@@ -0,0 +1,144 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+)
+
+const (
+	// bootstrapStepNameLimit is the maximum length of a step name, commands may be long.
+	bootstrapStepNameLimit = 256
+	// bootstrapStepOutputLimit is the maximum length of the output kept for a step. The end of the output is kept, as
+	// it usually tells why a command failed.
+	bootstrapStepOutputLimit = 1024
+)
+
+// bootstrapStepNames returns the names of the steps uploading the given files and running the given commands, in
+// the order they are run.
+func bootstrapStepNames(files []provisioner.File, commands []string) []string {
+	names := make([]string, 0, len(files)+len(commands))
+	for _, file := range files {
+		names = append(names, "Upload "+file.Path)
+	}
+	return append(names, commands...)
+}
+
+// bootstrapReporter records the progress of the bootstrap steps in the machine status.
+type bootstrapReporter struct {
+	machine *infrastructure.RemoteMachine
+	// publish persists the machine status while the bootstrap runs, so the progress is visible before it finishes.
+	publish func(ctx context.Context) error
+	log     logr.Logger
+}
+
+// init resets the bootstrap steps of the machine to the given pending steps.
+func (r *bootstrapReporter) init(ctx context.Context, names []string) {
+	steps := make([]infrastructure.BootstrapStep, 0, len(names))
+	for _, name := range names {
+		steps = append(steps, infrastructure.BootstrapStep{
+			Name:  truncateStepName(name),
+			Phase: infrastructure.BootstrapStepPending,
+		})
+	}
+	r.machine.Status.BootstrapSteps = steps
+	r.machine.Status.CurrentStep = ""
+	r.flush(ctx)
+}
+
+// start marks the given step as running.
+func (r *bootstrapReporter) start(ctx context.Context, i int) {
+	step := &r.machine.Status.BootstrapSteps[i]
+	step.Phase = infrastructure.BootstrapStepRunning
+	step.StartTime = new(metav1.Now())
+	r.machine.Status.CurrentStep = step.Name
+	r.flush(ctx)
+}
+
+// skip marks the given step as completed by a previous attempt.
+func (r *bootstrapReporter) skip(i int) {
+	step := &r.machine.Status.BootstrapSteps[i]
+	step.Phase = infrastructure.BootstrapStepSucceeded
+	step.Message = "Completed by a previous attempt"
+}
+
+// finish records the outcome of the given step.
+func (r *bootstrapReporter) finish(ctx context.Context, i int, output string, err error) {
+	step := &r.machine.Status.BootstrapSteps[i]
+	if step.StartTime != nil {
+		step.Duration = &metav1.Duration{Duration: time.Since(step.StartTime.Time).Round(time.Millisecond)}
+	}
+	step.Output = truncateStepOutput(output)
+
+	// Both the SSH and the WinRM exit errors report the exit status of the command.
+	var exitErr interface{ ExitStatus() int }
+	switch {
+	case err == nil:
+		step.Phase = infrastructure.BootstrapStepSucceeded
+		step.ExitCode = new(int32(0))
+	case errors.As(err, &exitErr):
+		step.Phase = infrastructure.BootstrapStepFailed
+		step.ExitCode = new(int32(exitErr.ExitStatus()))
+	default:
+		step.Phase = infrastructure.BootstrapStepFailed
+		step.Message = err.Error()
+	}
+	r.flush(ctx)
+}
+
+func (r *bootstrapReporter) flush(ctx context.Context) {
+	if r.publish == nil {
+		return
+	}
+	if err := r.publish(ctx); err != nil {
+		r.log.Error(err, "Failed to publish bootstrap progress")
+	}
+}
+
+// failedBootstrapStep returns the first failed bootstrap step of the machine, if any.
+func failedBootstrapStep(rm *infrastructure.RemoteMachine) *infrastructure.BootstrapStep {
+	for i := range rm.Status.BootstrapSteps {
+		if rm.Status.BootstrapSteps[i].Phase == infrastructure.BootstrapStepFailed {
+			return &rm.Status.BootstrapSteps[i]
+		}
+	}
+	return nil
+}
+
+func truncateStepName(name string) string {
+	if len(name) <= bootstrapStepNameLimit {
+		return name
+	}
+	return strings.ToValidUTF8(name[:bootstrapStepNameLimit], "") + "..."
+}
+
+func truncateStepOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) <= bootstrapStepOutputLimit {
+		return output
+	}
+	return "..." + strings.ToValidUTF8(output[len(output)-bootstrapStepOutputLimit:], "")
+}
@@ -0,0 +1,172 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+)
+
+func TestBootstrapReporter(t *testing.T) {
+	rm := &infrastructure.RemoteMachine{}
+	published := 0
+	r := &bootstrapReporter{
+		machine: rm,
+		publish: func(context.Context) error {
+			published++
+			return nil
+		},
+		log: logr.Discard(),
+	}
+	ctx := context.Background()
+
+	names := bootstrapStepNames([]provisioner.File{{Path: "/etc/k0s.token"}}, []string{"k0s install worker", "k0s start"})
+	r.init(ctx, names)
+	require.Len(t, rm.Status.BootstrapSteps, 3)
+	assert.Equal(t, "Upload /etc/k0s.token", rm.Status.BootstrapSteps[0].Name)
+	for _, step := range rm.Status.BootstrapSteps {
+		assert.Equal(t, infrastructure.BootstrapStepPending, step.Phase)
+	}
+
+	r.start(ctx, 0)
+	assert.Equal(t, infrastructure.BootstrapStepRunning, rm.Status.BootstrapSteps[0].Phase)
+	assert.Equal(t, "Upload /etc/k0s.token", rm.Status.CurrentStep)
+	r.finish(ctx, 0, "", nil)
+
+	r.start(ctx, 1)
+	r.finish(ctx, 1, "permission denied\n", errors.New("connection lost"))
+
+	assert.Equal(t, 5, published)
+	assert.Equal(t, "k0s install worker", rm.Status.CurrentStep)
+
+	uploaded := rm.Status.BootstrapSteps[0]
+	assert.Equal(t, infrastructure.BootstrapStepSucceeded, uploaded.Phase)
+	assert.Equal(t, int32(0), *uploaded.ExitCode)
+	assert.NotNil(t, uploaded.Duration)
+
+	failed := rm.Status.BootstrapSteps[1]
+	assert.Equal(t, infrastructure.BootstrapStepFailed, failed.Phase)
+	assert.Nil(t, failed.ExitCode)
+	assert.Equal(t, "permission denied", failed.Output)
+	assert.Equal(t, "connection lost", failed.Message)
+	assert.Equal(t, "k0s install worker", failedBootstrapStep(rm).Name)
+
+	assert.Equal(t, infrastructure.BootstrapStepPending, rm.Status.BootstrapSteps[2].Phase)
+}
+
+func TestTruncateStepOutput(t *testing.T) {
+	assert.Equal(t, "done", truncateStepOutput("  done\n"))
+
+	output := strings.Repeat("a", bootstrapStepOutputLimit) + "error"
+	truncated := truncateStepOutput(output)
+	assert.True(t, strings.HasPrefix(truncated, "..."))
+	assert.True(t, strings.HasSuffix(truncated, "error"))
+	assert.Len(t, truncated, bootstrapStepOutputLimit+3)
+}
+
+func TestParseJobSteps(t *testing.T) {
+	var script bytes.Buffer
+	writeJobStep(&script, 0, "scp file host:/etc/file")
+	assert.Equal(t, `echo "k0smotron-step-start 0 $(date +%s)"
+{ scp file host:/etc/file; } 2>&1
+rc=$?
+echo "k0smotron-step-end 0 $rc $(date +%s)"
+[ $rc -eq 0 ] || exit $rc
+`, script.String())
+
+	logs := []byte(`k0smotron-step-start 0 1700000000
+k0smotron-step-end 0 0 1700000001
+k0smotron-step-start 1 1700000001
+installing k0s
+k0s: permission denied
+k0smotron-step-end 1 1 1700000004
+`)
+	steps := []infrastructure.BootstrapStep{
+		{Name: "Upload /etc/file", Phase: infrastructure.BootstrapStepPending},
+		{Name: "k0s install worker", Phase: infrastructure.BootstrapStepPending},
+		{Name: "k0s start", Phase: infrastructure.BootstrapStepPending},
+	}
+	parseJobSteps(logs, steps)
+
+	assert.Equal(t, infrastructure.BootstrapStepSucceeded, steps[0].Phase)
+	assert.Equal(t, int32(0), *steps[0].ExitCode)
+	assert.Equal(t, time.Second, steps[0].Duration.Duration)
+
+	assert.Equal(t, infrastructure.BootstrapStepFailed, steps[1].Phase)
+	assert.Equal(t, int32(1), *steps[1].ExitCode)
+	assert.Equal(t, 3*time.Second, steps[1].Duration.Duration)
+	assert.Equal(t, "installing k0s\nk0s: permission denied", steps[1].Output)
+	assert.Equal(t, time.Unix(1700000001, 0), steps[1].StartTime.Time)
+
+	assert.Equal(t, infrastructure.BootstrapStepPending, steps[2].Phase)
+}
+
+func TestJobStepsStopAtFailure(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	var script bytes.Buffer
+	writeJobStep(&script, 0, "echo uploaded")
+	writeJobStep(&script, 1, "sh -c 'echo failing; exit 3'")
+	writeJobStep(&script, 2, "echo started")
+
+	// The step following the failed one is not run, and the script exits with the code of the failed step.
+	logs, err := exec.Command(sh, "-c", script.String()).Output()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.ExitCode())
+	assert.NotContains(t, string(logs), "started")
+
+	steps := []infrastructure.BootstrapStep{
+		{Name: "Upload /etc/file", Phase: infrastructure.BootstrapStepPending},
+		{Name: "k0s install worker", Phase: infrastructure.BootstrapStepPending},
+		{Name: "k0s start", Phase: infrastructure.BootstrapStepPending},
+	}
+	parseJobSteps(logs, steps)
+	assert.Equal(t, infrastructure.BootstrapStepSucceeded, steps[0].Phase)
+	assert.Equal(t, infrastructure.BootstrapStepFailed, steps[1].Phase)
+	assert.Equal(t, int32(3), *steps[1].ExitCode)
+	assert.Equal(t, "failing", steps[1].Output)
+	assert.Equal(t, infrastructure.BootstrapStepPending, steps[2].Phase)
+}
+
+func TestParseJobStepsUnfinished(t *testing.T) {
+	logs := []byte("k0smotron-step-start 0 1700000000\nconnecting\n")
+	steps := []infrastructure.BootstrapStep{{Name: "k0s install worker", Phase: infrastructure.BootstrapStepPending}}
+	parseJobSteps(logs, steps)
+
+	assert.Equal(t, infrastructure.BootstrapStepFailed, steps[0].Phase)
+	assert.Nil(t, steps[0].ExitCode)
+	assert.Equal(t, "connecting", steps[0].Output)
+	assert.Equal(t, "The step did not finish", steps[0].Message)
+}
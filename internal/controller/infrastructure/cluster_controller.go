@@ -18,14 +18,19 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
@@ -40,6 +45,7 @@ type ClusterController struct {
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=remoteclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=remoteclusters/status,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=pooledremotemachines,verbs=get;list;watch
 
 // Reconcile reconciles the RemoteCluster resource and ensures it is in a ready state.
 func (r *ClusterController) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -56,8 +62,14 @@ func (r *ClusterController) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, err
 	}
 
-	// Nothing really to do, except put the cluster in a ready state
+	// Nothing really to do, except publish the failure domains of the pools and put the cluster in a ready state
 	if c.ObjectMeta.DeletionTimestamp.IsZero() {
+		failureDomains, err := r.failureDomains(ctx, c)
+		if err != nil {
+			log.Error(err, "Failed to get failure domains")
+			return ctrl.Result{}, err
+		}
+		c.Status.FailureDomains = failureDomains
 		c.Status.Initialization.Provisioned = new(true)
 		conditions.Set(c, metav1.Condition{
 			Type:   "Ready",
@@ -73,10 +85,55 @@ func (r *ClusterController) Reconcile(ctx context.Context, req ctrl.Request) (re
 	return ctrl.Result{}, nil
 }
 
+// failureDomains returns the failure domains of the PooledRemoteMachines of the cluster pools, sorted by name.
+func (r *ClusterController) failureDomains(ctx context.Context, c *infrastructure.RemoteCluster) ([]clusterv1.FailureDomain, error) {
+	pooledMachines := &infrastructure.PooledRemoteMachineList{}
+	if err := r.List(ctx, pooledMachines, client.InNamespace(c.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pooled machines: %w", err)
+	}
+
+	names := map[string]struct{}{}
+	for _, pm := range pooledMachines.Items {
+		if pm.Spec.FailureDomain == "" {
+			continue
+		}
+		if len(c.Spec.Pools) > 0 && !slices.Contains(c.Spec.Pools, pm.Spec.Pool) {
+			continue
+		}
+		names[pm.Spec.FailureDomain] = struct{}{}
+	}
+
+	var failureDomains []clusterv1.FailureDomain
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		failureDomains = append(failureDomains, clusterv1.FailureDomain{
+			Name:         name,
+			ControlPlane: new(true),
+		})
+	}
+	return failureDomains, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterController) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&infrastructure.RemoteCluster{}).
+		// The failure domains are updated when the pooled machines change.
+		Watches(&infrastructure.PooledRemoteMachine{}, handler.EnqueueRequestsFromMapFunc(r.pooledMachineToRemoteClusters)).
 		Complete(r)
 }
+
+// pooledMachineToRemoteClusters maps a PooledRemoteMachine to the RemoteClusters of its namespace.
+func (r *ClusterController) pooledMachineToRemoteClusters(ctx context.Context, o client.Object) []ctrl.Request {
+	clusters := &infrastructure.RemoteClusterList{}
+	if err := r.List(ctx, clusters, client.InNamespace(o.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list RemoteClusters")
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(clusters.Items))
+	for _, c := range clusters.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&c)})
+	}
+	return requests
+}
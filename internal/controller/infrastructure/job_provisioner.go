@@ -21,6 +21,10 @@ import (
 	"crypto/md5"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -35,6 +39,14 @@ import (
 	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
 )
 
+const (
+	// jobWatchTimeout is the maximum time the provisioning job is watched to report its bootstrap steps.
+	jobWatchTimeout = time.Hour
+
+	jobStepStartMarker = "k0smotron-step-start"
+	jobStepEndMarker   = "k0smotron-step-end"
+)
+
 var patchOpts []client.PatchOption = []client.PatchOption{
 	client.FieldOwner("k0smotron-operator"),
 	client.ForceOwnership,
@@ -52,6 +64,7 @@ type JobProvisioner struct {
 	machine       *v1beta2.Machine
 	remoteMachine *infrastructure.RemoteMachine
 	provisionJob  *infrastructure.ProvisionJob
+	reporter      *bootstrapReporter
 	log           logr.Logger
 }
 
@@ -115,23 +128,33 @@ func (p *JobProvisioner) Provision(ctx context.Context) error {
 		return fmt.Errorf("failed to create job: %w", err)
 	}
 
-	watch, err := p.clientSet.BatchV1().Jobs(job.Namespace).Watch(ctx, metav1.ListOptions{
+	// The steps are run by the job, their outcome is reported once it has finished.
+	p.reporter.init(ctx, bootstrapStepNames(p.cloudInit.Files, p.cloudInit.Commands))
+
+	// The job outlives the reconciliation, so it is watched with a context which is not cancelled with it.
+	watchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobWatchTimeout)
+	watch, err := p.clientSet.BatchV1().Jobs(job.Namespace).Watch(watchCtx, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", job.Name),
 	})
 	if err != nil {
+		cancel()
 		return fmt.Errorf("failed to watch job: %w", err)
 	}
 
 	go func() {
+		defer cancel()
+		defer watch.Stop()
 		for event := range watch.ResultChan() {
 			j, ok := event.Object.(*batchv1.Job)
 			if !ok {
 				continue
 			}
 			for _, c := range j.Status.Conditions {
-				if c.Type == batchv1.JobComplete {
-					// TODO: update machine status
-					break
+				if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == v1.ConditionTrue {
+					if err := p.reportJobSteps(watchCtx, j); err != nil {
+						p.log.Error(err, "Failed to report bootstrap steps of the provisioning job", "job", j.Name)
+					}
+					return
 				}
 			}
 		}
@@ -140,6 +163,106 @@ func (p *JobProvisioner) Provision(ctx context.Context) error {
 	return nil
 }
 
+// writeJobStep writes a bootstrap step to the job entrypoint script. The step is surrounded by markers which are
+// parsed from the job logs to report the step, and the script stops at the first failed step.
+func writeJobStep(buf *bytes.Buffer, step int, cmd string) {
+	fmt.Fprintf(buf, "echo \"%s %d $(date +%%s)\"\n", jobStepStartMarker, step)
+	fmt.Fprintf(buf, "{ %s; } 2>&1\n", cmd)
+	buf.WriteString("rc=$?\n")
+	fmt.Fprintf(buf, "echo \"%s %d $rc $(date +%%s)\"\n", jobStepEndMarker, step)
+	buf.WriteString("[ $rc -eq 0 ] || exit $rc\n")
+}
+
+// reportJobSteps reports the bootstrap steps of the machine from the logs of the finished job.
+func (p *JobProvisioner) reportJobSteps(ctx context.Context, job *batchv1.Job) error {
+	pods, err := p.clientSet.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", batchv1.JobNameLabel, job.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list job pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no pods found for job %s", job.Name)
+	}
+	// The last pod ran the last attempt of the job.
+	pod := slices.MaxFunc(pods.Items, func(a, b v1.Pod) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+	logs, err := p.clientSet.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get job logs: %w", err)
+	}
+
+	rm := &infrastructure.RemoteMachine{}
+	if err := p.client.Get(ctx, client.ObjectKeyFromObject(p.remoteMachine), rm); err != nil {
+		return fmt.Errorf("failed to get remote machine: %w", err)
+	}
+	patch := client.MergeFrom(rm.DeepCopy())
+	parseJobSteps(logs, rm.Status.BootstrapSteps)
+	for _, step := range rm.Status.BootstrapSteps {
+		if step.Phase == infrastructure.BootstrapStepPending {
+			break
+		}
+		rm.Status.CurrentStep = step.Name
+	}
+	if err := p.client.Status().Patch(ctx, rm, patch); err != nil {
+		return fmt.Errorf("failed to patch remote machine status: %w", err)
+	}
+	return nil
+}
+
+// parseJobSteps updates the given steps from the markers written by the job entrypoint in its logs. A step started
+// but not ended, if the job was terminated, is reported as failed.
+func parseJobSteps(logs []byte, steps []infrastructure.BootstrapStep) {
+	var (
+		current = -1
+		output  strings.Builder
+	)
+	for line := range strings.Lines(string(logs)) {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 3 && fields[0] == jobStepStartMarker:
+			i, err := strconv.Atoi(fields[1])
+			if err != nil || i < 0 || i >= len(steps) {
+				continue
+			}
+			current = i
+			output.Reset()
+			steps[i].Phase = infrastructure.BootstrapStepRunning
+			if start, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+				steps[i].StartTime = new(metav1.NewTime(time.Unix(start, 0)))
+			}
+		case len(fields) == 4 && fields[0] == jobStepEndMarker:
+			i, err := strconv.Atoi(fields[1])
+			if err != nil || i != current {
+				continue
+			}
+			rc, err := strconv.Atoi(fields[2])
+			if err != nil {
+				continue
+			}
+			steps[i].ExitCode = new(int32(rc))
+			steps[i].Phase = infrastructure.BootstrapStepSucceeded
+			if rc != 0 {
+				steps[i].Phase = infrastructure.BootstrapStepFailed
+			}
+			if end, err := strconv.ParseInt(fields[3], 10, 64); err == nil && steps[i].StartTime != nil {
+				steps[i].Duration = &metav1.Duration{Duration: time.Unix(end, 0).Sub(steps[i].StartTime.Time)}
+			}
+			steps[i].Output = truncateStepOutput(output.String())
+			current = -1
+		case current >= 0:
+			output.WriteString(line)
+		}
+	}
+
+	if current >= 0 {
+		steps[current].Phase = infrastructure.BootstrapStepFailed
+		steps[current].Output = truncateStepOutput(output.String())
+		steps[current].Message = "The step did not finish"
+	}
+}
+
 func (p *JobProvisioner) extractCloudInit(cloudInit *provisioner.InputProvisionData) (volume v1.Volume, volumeMounts []v1.VolumeMount, secretData map[string][]byte) {
 	machineDSN := p.machineDSN()
 
@@ -166,6 +289,7 @@ func (p *JobProvisioner) extractCloudInit(cloudInit *provisioner.InputProvisionD
 
 	secretData = make(map[string][]byte)
 
+	step := 0
 	for _, file := range cloudInit.Files {
 		fileName := genFileName(file.Path)
 		secretData[fileName] = []byte(file.Content)
@@ -175,8 +299,9 @@ func (p *JobProvisioner) extractCloudInit(cloudInit *provisioner.InputProvisionD
 			Path: fileName,
 		})
 
-		buf.WriteString(fmt.Sprintf("%s /var/lib/bootstrap-data/%s %s:%s\n", scpCommand, fileName, machineDSN, file.Path))
-		buf.WriteString(fmt.Sprintf("%s chmod %s %s\n", sshCommand, file.Permissions, file.Path))
+		writeJobStep(&buf, step, fmt.Sprintf("%s /var/lib/bootstrap-data/%s %s:%s && %s chmod %s %s",
+			scpCommand, fileName, machineDSN, file.Path, sshCommand, file.Permissions, file.Path))
+		step++
 	}
 	volumeMounts = append(volumeMounts, v1.VolumeMount{
 		Name:      "bootstrap-data",
@@ -187,7 +312,8 @@ func (p *JobProvisioner) extractCloudInit(cloudInit *provisioner.InputProvisionD
 		if p.remoteMachine.Spec.UseSudo {
 			cmd = fmt.Sprintf("sudo su -c '%s'", cmd)
 		}
-		buf.WriteString(fmt.Sprintf("%s \"%s\"\n", sshCommand, cmd))
+		writeJobStep(&buf, step, fmt.Sprintf("%s \"%s\"", sshCommand, cmd))
+		step++
 	}
 	secretData["k0smotron-entrypoint.sh"] = buf.Bytes()
 
@@ -0,0 +1,304 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/go-logr/logr"
+	"github.com/k0sproject/rig/exec"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+const (
+	// defaultCleanPath is the k0s data directory, which must not exist on a clean machine.
+	defaultCleanPath = "/var/lib/k0s"
+	// defaultWindowsCleanPath is the k0s data directory of Windows machines.
+	defaultWindowsCleanPath = `C:\var\lib\k0s`
+	// defaultHealthCheckInterval is the time between two probes of a machine when the health check has no interval.
+	defaultHealthCheckInterval = 5 * time.Minute
+	// minHealthCheckInterval is the shortest time between two probes of a machine. Each probe updates the status of
+	// the machine, which triggers a new reconciliation, so a zero interval would probe the machine in a loop.
+	minHealthCheckInterval = 30 * time.Second
+)
+
+// PooledRemoteMachineController probes the health of the free PooledRemoteMachines which define a health check, and
+// quarantines the unhealthy ones so they are not reserved.
+type PooledRemoteMachineController struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// probeResult is the outcome of a health probe. A healthy machine has no reason.
+type probeResult struct {
+	reason  infrastructure.PoolQuarantineReason
+	message string
+	// poweredOff is true if the machine was not probed because it is powered off.
+	poweredOff bool
+	// poweredOn is true if the machine was powered on to be probed once booted.
+	poweredOn bool
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=pooledremotemachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=pooledremotemachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile probes the PooledRemoteMachine and updates its quarantine status.
+func (r *PooledRemoteMachineController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithValues("pooledremotemachine", req.NamespacedName)
+
+	pm := &infrastructure.PooledRemoteMachine{}
+	if err := r.Get(ctx, req.NamespacedName, pm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get PooledRemoteMachine")
+		return ctrl.Result{}, err
+	}
+
+	// Reserved machines are in use by a RemoteMachine, they are probed once returned to the pool.
+	if pm.Spec.HealthCheck == nil || pm.Status.Reserved || !pm.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	interval := healthCheckInterval(pm.Spec.HealthCheck)
+	if pm.Status.LastProbeTime != nil {
+		if remaining := time.Until(pm.Status.LastProbeTime.Add(interval)); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	result := r.probe(ctx, log, pm)
+	if result.poweredOn {
+		return ctrl.Result{RequeueAfter: powerOnBootDelay}, nil
+	}
+
+	released := false
+	pm.Status.LastProbeTime = &metav1.Time{Time: time.Now()}
+	switch {
+	case result.poweredOff:
+		// A free machine is powered off once cleaned up, it is probed when reserved.
+	case result.reason != "":
+		log.Info("Quarantining pooled machine", "reason", result.reason, "message", result.message)
+		pm.Status.Quarantine(result.reason, result.message)
+	case pm.Status.QuarantineReason == infrastructure.PoolQuarantineCleanupFailed && pm.Spec.HealthCheck.SkipCleanCheck:
+		// The probes do not tell whether the machine has been cleaned up since, it has to be released manually.
+	case pm.Status.Quarantined:
+		log.Info("Releasing pooled machine from quarantine", "reason", pm.Status.QuarantineReason)
+		pm.Status.Release()
+		released = true
+	}
+
+	if err := r.Status().Update(ctx, pm); err != nil {
+		log.Error(err, "Failed to update PooledRemoteMachine status")
+		return ctrl.Result{}, err
+	}
+
+	// A released machine is powered off like the machines returned to the pool, it may have been powered on to be probed.
+	if released {
+		if err := (&RemoteMachineController{Client: r.Client}).powerOff(ctx, remoteMachineFromPool(pm)); err != nil {
+			log.Error(err, "Failed to power off released pooled machine")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// healthCheckInterval returns the time between two probes of the machine.
+func healthCheckInterval(healthCheck *infrastructure.PoolHealthCheck) time.Duration {
+	if healthCheck.Interval.Duration == 0 {
+		return defaultHealthCheckInterval
+	}
+	return max(healthCheck.Interval.Duration, minHealthCheckInterval)
+}
+
+// remoteMachineFromPool returns a RemoteMachine connecting to the pooled machine the same way a RemoteMachine reserving
+// it would.
+func remoteMachineFromPool(pm *infrastructure.PooledRemoteMachine) *infrastructure.RemoteMachine {
+	rm := &infrastructure.RemoteMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: pm.Name, Namespace: pm.Namespace},
+	}
+	populateRemoteMachineFromPool(rm, pm)
+	return rm
+}
+
+// probe checks that the machine is reachable, that its verification command succeeds and that no files of a previous
+// installation are left on it.
+func (r *PooledRemoteMachineController) probe(ctx context.Context, log logr.Logger, pm *infrastructure.PooledRemoteMachine) probeResult {
+	rm := remoteMachineFromPool(pm)
+
+	// The machines powered off once returned to the pool are powered on when reserved. A quarantined machine is powered
+	// on to be probed, otherwise it would never be released.
+	if rm.Spec.PowerManagement != nil {
+		redfish, err := (&RemoteMachineController{Client: r.Client}).getRedfishClient(ctx, rm)
+		if err != nil {
+			return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: err.Error()}
+		}
+		state, err := redfish.powerState(ctx)
+		if err != nil {
+			return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: err.Error()}
+		}
+		if state == redfishPowerOff {
+			if !pm.Status.Quarantined {
+				return probeResult{poweredOff: true}
+			}
+			log.Info("Powering on quarantined pooled machine to probe it", "reason", pm.Status.QuarantineReason)
+			if err := redfish.reset(ctx, redfishResetOn); err != nil {
+				return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: err.Error()}
+			}
+			return probeResult{poweredOn: true}
+		}
+	}
+
+	healthCheck := pm.Spec.HealthCheck
+	var cleanPaths []string
+	if !healthCheck.SkipCleanCheck {
+		cleanPaths = healthCheck.CleanPaths
+		if len(cleanPaths) == 0 {
+			cleanPaths = []string{defaultCleanPath}
+			if rm.Spec.Transport == infrastructure.TransportWinRM {
+				cleanPaths = []string{defaultWindowsCleanPath}
+			}
+		}
+	}
+
+	if rm.Spec.Transport == infrastructure.TransportWinRM {
+		return r.probeWinRM(ctx, log, rm, healthCheck.Command, cleanPaths)
+	}
+
+	// The host keys accepted on the first probe are kept in the pooled machine status, so that the later probes
+	// verify them with the TrustOnFirstUse policy.
+	rm.Status.HostKey = pm.Status.HostKey
+	rm.Status.BastionHostKey = pm.Status.BastionHostKey
+	result := r.probeSSH(ctx, log, rm, healthCheck.Command, cleanPaths)
+	pm.Status.HostKey = rm.Status.HostKey
+	pm.Status.BastionHostKey = rm.Status.BastionHostKey
+	return result
+}
+
+func (r *PooledRemoteMachineController) probeSSH(ctx context.Context, log logr.Logger, rm *infrastructure.RemoteMachine, command string, cleanPaths []string) probeResult {
+	secrets := &RemoteMachineController{Client: r.Client}
+	if rm.Spec.SSHKeyRef.Name == "" {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: "sshKeyRef is not defined"}
+	}
+	sshKey, err := secrets.getSSHKey(ctx, rm)
+	if err != nil {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: fmt.Sprintf("failed to get ssh key: %s", err)}
+	}
+	knownHosts, err := secrets.getKnownHosts(ctx, rm)
+	if err != nil {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: fmt.Sprintf("failed to get known hosts: %s", err)}
+	}
+	bastionSSHKey, err := secrets.getBastionSSHKey(ctx, rm)
+	if err != nil {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: err.Error()}
+	}
+
+	p := &SSHProvisioner{
+		machine:       rm,
+		sshKey:        sshKey,
+		knownHosts:    knownHosts,
+		bastionSSHKey: bastionSSHKey,
+		log:           log,
+	}
+	connection, err := p.connect(ctx)
+	if err != nil {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: err.Error()}
+	}
+	defer connection.Disconnect()
+
+	var execOpts []exec.Option
+	if rm.Spec.UseSudo {
+		execOpts = append(execOpts, exec.Sudo(connection))
+	}
+
+	if command != "" {
+		if output, err := connection.ExecOutput(command, execOpts...); err != nil {
+			return probeResult{reason: infrastructure.PoolQuarantineVerificationFailed, message: fmt.Sprintf("%s: %s", err, truncateStepOutput(output))}
+		}
+	}
+
+	if len(cleanPaths) > 0 {
+		cmd := fmt.Sprintf(`for p in %s; do if [ -e "$p" ]; then echo "$p exists"; exit 1; fi; done`, shellescape.QuoteCommand(cleanPaths))
+		if output, err := connection.ExecOutput(cmd, execOpts...); err != nil {
+			return probeResult{reason: infrastructure.PoolQuarantineDiskNotClean, message: truncateStepOutput(output)}
+		}
+	}
+
+	return probeResult{}
+}
+
+func (r *PooledRemoteMachineController) probeWinRM(ctx context.Context, log logr.Logger, rm *infrastructure.RemoteMachine, command string, cleanPaths []string) probeResult {
+	username, password, caCert, err := (&RemoteMachineController{Client: r.Client}).getWinRMCredentials(ctx, rm)
+	if err != nil {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: err.Error()}
+	}
+
+	p := &WinRMProvisioner{
+		machine:  rm,
+		username: username,
+		password: password,
+		caCert:   caCert,
+		log:      log,
+	}
+	client, err := p.client()
+	if err != nil {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: err.Error()}
+	}
+	if output, err := p.exec(ctx, client, "hostname"); err != nil {
+		return probeResult{reason: infrastructure.PoolQuarantineUnreachable, message: fmt.Sprintf("%s: %s", err, truncateStepOutput(output))}
+	}
+
+	if command != "" {
+		if output, err := p.exec(ctx, client, command); err != nil {
+			return probeResult{reason: infrastructure.PoolQuarantineVerificationFailed, message: fmt.Sprintf("%s: %s", err, truncateStepOutput(output))}
+		}
+	}
+
+	if len(cleanPaths) > 0 {
+		checks := make([]string, 0, len(cleanPaths))
+		for _, path := range cleanPaths {
+			checks = append(checks, fmt.Sprintf(`if (Test-Path -Path %[1]s) { Write-Output (%[1]s + ' exists'); exit 1 }`, quotePS(path)))
+		}
+		if output, err := p.execPS(ctx, client, strings.Join(checks, "\n")); err != nil {
+			return probeResult{reason: infrastructure.PoolQuarantineDiskNotClean, message: truncateStepOutput(output)}
+		}
+	}
+
+	return probeResult{}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PooledRemoteMachineController) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&infrastructure.PooledRemoteMachine{}).
+		Complete(r)
+}
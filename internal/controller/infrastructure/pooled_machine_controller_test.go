@@ -0,0 +1,168 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/rig/pkg/ssh/hostkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+// winrmPooledMachine returns a pooled machine probed through the given WinRM server, and the secret of its credentials.
+func winrmPooledMachine(t *testing.T, server *winrmServer) (*infrastructure.PooledRemoteMachine, *v1.Secret) {
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(ts.URL, "http://"))
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	pm := pooledMachine("windows", "workers", "", nil)
+	pm.Spec.Machine.Address = host
+	pm.Spec.Machine.Transport = infrastructure.TransportWinRM
+	pm.Spec.Machine.WinRM = &infrastructure.WinRM{Port: portNumber, CredentialsRef: infrastructure.SecretRef{Name: "winrm"}}
+	pm.Spec.HealthCheck = &infrastructure.PoolHealthCheck{Interval: metav1.Duration{Duration: time.Minute}}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "winrm", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	return pm, secret
+}
+
+func TestPooledMachineHealthCheck(t *testing.T) {
+	k0sInstalled := true
+	pm, secret := winrmPooledMachine(t, &winrmServer{
+		result: func(cmd string) (string, int) {
+			if k0sInstalled && strings.Contains(cmd, "Test-Path") {
+				return `C:\var\lib\k0s exists`, 1
+			}
+			return "", 0
+		},
+	})
+	c := newFakeClient(t, pm, secret)
+	r := &PooledRemoteMachineController{Client: c}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pm)}
+
+	res, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, res.RequeueAfter)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.True(t, pm.Status.Quarantined)
+	assert.Equal(t, infrastructure.PoolQuarantineDiskNotClean, pm.Status.QuarantineReason)
+	assert.Equal(t, `C:\var\lib\k0s exists`, pm.Status.QuarantineMessage)
+	require.NotNil(t, pm.Status.LastProbeTime)
+
+	// The machine is not probed again before the interval has passed.
+	k0sInstalled = false
+	res, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Positive(t, res.RequeueAfter)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.True(t, pm.Status.Quarantined)
+
+	pm.Status.LastProbeTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	require.NoError(t, c.Status().Update(ctx, pm))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.False(t, pm.Status.Quarantined)
+	assert.Empty(t, pm.Status.QuarantineReason)
+
+	// A failed cleanup is not released when the disk is not checked.
+	pm.Spec.HealthCheck.SkipCleanCheck = true
+	require.NoError(t, c.Update(ctx, pm))
+	pm.Status.Quarantine(infrastructure.PoolQuarantineCleanupFailed, "k0s reset failed")
+	pm.Status.LastProbeTime = nil
+	require.NoError(t, c.Status().Update(ctx, pm))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.Equal(t, infrastructure.PoolQuarantineCleanupFailed, pm.Status.QuarantineReason)
+}
+
+func TestPooledMachineHealthCheckHostKey(t *testing.T) {
+	addr, key := startSSHServer(t)
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+
+	pm := pooledMachine("linux", "workers", "", nil)
+	pm.Spec.Machine.Address = addr.IP.String()
+	pm.Spec.Machine.Port = addr.Port
+	pm.Spec.Machine.SSHKeyRef = infrastructure.SecretRef{Name: "ssh-key"}
+	pm.Spec.HealthCheck = &infrastructure.PoolHealthCheck{SkipCleanCheck: true}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ssh-key", Namespace: "default"},
+		Data:       map[string][]byte{"value": pem.EncodeToMemory(block)},
+	}
+	c := newFakeClient(t, pm, secret)
+	r := &PooledRemoteMachineController{Client: c}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pm)}
+
+	// The host key is trusted on the first probe. The test server does not run commands, so the probe fails once the
+	// host key has been verified.
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.Equal(t, authorizedKey(key), pm.Status.HostKey)
+	assert.NotContains(t, pm.Status.QuarantineMessage, hostkey.ErrHostKeyMismatch.Error())
+
+	// The later probes only accept that key.
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherSigner, err := ssh.NewSignerFromKey(otherPriv)
+	require.NoError(t, err)
+	pm.Status.HostKey = authorizedKey(otherSigner.PublicKey())
+	pm.Status.LastProbeTime = nil
+	require.NoError(t, c.Status().Update(ctx, pm))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.True(t, pm.Status.Quarantined)
+	assert.Equal(t, infrastructure.PoolQuarantineUnreachable, pm.Status.QuarantineReason)
+	assert.Contains(t, pm.Status.QuarantineMessage, hostkey.ErrHostKeyMismatch.Error())
+	assert.Equal(t, authorizedKey(otherSigner.PublicKey()), pm.Status.HostKey)
+}
+
+func TestHealthCheckInterval(t *testing.T) {
+	assert.Equal(t, defaultHealthCheckInterval, healthCheckInterval(&infrastructure.PoolHealthCheck{}))
+	assert.Equal(t, minHealthCheckInterval, healthCheckInterval(&infrastructure.PoolHealthCheck{Interval: metav1.Duration{Duration: time.Second}}))
+	assert.Equal(t, time.Minute, healthCheckInterval(&infrastructure.PoolHealthCheck{Interval: metav1.Duration{Duration: time.Minute}}))
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+// powerOnBootDelay is the time waited for a machine to boot once powered on, before it is provisioned.
+const powerOnBootDelay = 30 * time.Second
+
+// getRedfishClient returns a client of the Redfish service managing the power of the machine.
+func (r *RemoteMachineController) getRedfishClient(ctx context.Context, rm *infrastructure.RemoteMachine) (*redfishClient, error) {
+	spec := &rm.Spec.PowerManagement.Redfish
+	secret := &v1.Secret{}
+	key := client.ObjectKey{
+		Namespace: rm.Namespace,
+		Name:      spec.CredentialsRef.Name,
+	}
+	if err := r.Client.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get redfish credentials secret: %w", err)
+	}
+
+	return newRedfishClient(spec, string(secret.Data["username"]), string(secret.Data["password"]), secret.Data["ca.crt"])
+}
+
+// powerOn powers the machine on, if it has power management. It returns true if the machine was powered off.
+func (r *RemoteMachineController) powerOn(ctx context.Context, rm *infrastructure.RemoteMachine) (bool, error) {
+	if rm.Spec.PowerManagement == nil {
+		return false, nil
+	}
+
+	redfish, err := r.getRedfishClient(ctx, rm)
+	if err != nil {
+		return false, err
+	}
+	state, err := redfish.powerState(ctx)
+	if err != nil {
+		return false, err
+	}
+	if state == redfishPowerOn {
+		return false, nil
+	}
+
+	log.FromContext(ctx).Info("Powering on machine", "powerState", state)
+	return true, redfish.reset(ctx, redfishResetOn)
+}
+
+// powerOff powers the machine off, if it has power management.
+func (r *RemoteMachineController) powerOff(ctx context.Context, rm *infrastructure.RemoteMachine) error {
+	if rm.Spec.PowerManagement == nil {
+		return nil
+	}
+
+	redfish, err := r.getRedfishClient(ctx, rm)
+	if err != nil {
+		return err
+	}
+	state, err := redfish.powerState(ctx)
+	if err != nil {
+		return err
+	}
+	if state == redfishPowerOff {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Powering off machine", "powerState", state)
+	return redfish.reset(ctx, redfishResetForceOff)
+}
+
+// powerCycleIfUnhealthy power cycles the machine, if it has power management, when the remediation of its unhealthy
+// Machine is delegated to an external remediation by a MachineHealthCheck. Without external remediation the owner of
+// the Machine deletes it, and power cycling the machine would break its cleanup. The machine is power cycled once each
+// time the Machine waits for external remediation.
+func (r *RemoteMachineController) powerCycleIfUnhealthy(ctx context.Context, rm *infrastructure.RemoteMachine, machine *clusterv1.Machine) error {
+	if rm.Spec.PowerManagement == nil || !machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	remediation := conditions.Get(machine, clusterv1.MachineExternallyRemediatedCondition)
+	if remediation == nil || remediation.Status != metav1.ConditionFalse ||
+		remediation.Reason != clusterv1.MachineExternallyRemediatedWaitingForRemediationReason {
+		return nil
+	}
+	if rm.Status.LastPowerCycleTime != nil && !rm.Status.LastPowerCycleTime.Before(&remediation.LastTransitionTime) {
+		return nil
+	}
+
+	redfish, err := r.getRedfishClient(ctx, rm)
+	if err != nil {
+		return err
+	}
+	state, err := redfish.powerState(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Power cycling unhealthy machine", "powerState", state)
+	resetType := redfishResetForceRestart
+	if state == redfishPowerOff {
+		resetType = redfishResetOn
+	}
+	if err := redfish.reset(ctx, resetType); err != nil {
+		return err
+	}
+
+	rm.Status.LastPowerCycleTime = new(metav1.Now())
+	return nil
+}
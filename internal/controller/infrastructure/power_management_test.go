@@ -0,0 +1,206 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+// redfishServer is a mocked Redfish service with a single computer system, recording the resets of the system.
+type redfishServer struct {
+	mu         sync.Mutex
+	powerState string
+	resets     []string
+}
+
+func (s *redfishServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/Systems":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}},
+		})
+	case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/Systems/1":
+		_ = json.NewEncoder(w).Encode(map[string]string{"PowerState": s.powerState})
+	case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset":
+		var body struct {
+			ResetType string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.resets = append(s.resets, body.ResetType)
+		s.powerState = redfishPowerOn
+		if body.ResetType == redfishResetForceOff {
+			s.powerState = redfishPowerOff
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newRedfishServer(t *testing.T, powerState string) (*redfishServer, *infrastructure.PowerManagement, *v1.Secret) {
+	server := &redfishServer{powerState: powerState}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	powerManagement := &infrastructure.PowerManagement{
+		Redfish: infrastructure.Redfish{Address: ts.URL, CredentialsRef: infrastructure.SecretRef{Name: "bmc"}},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc", Namespace: "default"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+	}
+	return server, powerManagement, secret
+}
+
+func TestPowerManagement(t *testing.T) {
+	ctx := context.Background()
+	server, powerManagement, secret := newRedfishServer(t, redfishPowerOff)
+	r := &RemoteMachineController{Client: newFakeClient(t, secret)}
+	rm := &infrastructure.RemoteMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "rm", Namespace: "default"},
+		Spec:       infrastructure.RemoteMachineSpec{PowerManagement: powerManagement},
+	}
+
+	poweredOn, err := r.powerOn(ctx, rm)
+	require.NoError(t, err)
+	assert.True(t, poweredOn)
+	poweredOn, err = r.powerOn(ctx, rm)
+	require.NoError(t, err)
+	assert.False(t, poweredOn)
+
+	machine := &clusterv1.Machine{}
+	require.NoError(t, r.powerCycleIfUnhealthy(ctx, rm, machine))
+	unhealthySince := metav1.NewTime(time.Now().Add(-time.Minute))
+	machine.Status.Conditions = []metav1.Condition{{
+		Type:               clusterv1.MachineHealthCheckSucceededCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterv1.MachineHealthCheckNodeDeletedReason,
+		LastTransitionTime: unhealthySince,
+	}, {
+		Type:               clusterv1.MachineOwnerRemediatedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterv1.MachineOwnerRemediatedWaitingForRemediationReason,
+		LastTransitionTime: unhealthySince,
+	}}
+	// A machine remediated by the owner of its Machine is deleted, not power cycled.
+	require.NoError(t, r.powerCycleIfUnhealthy(ctx, rm, machine))
+	assert.Nil(t, rm.Status.LastPowerCycleTime)
+
+	machine.Status.Conditions[1] = metav1.Condition{
+		Type:               clusterv1.MachineExternallyRemediatedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterv1.MachineExternallyRemediatedWaitingForRemediationReason,
+		LastTransitionTime: unhealthySince,
+	}
+	// Nor is a machine whose Machine is being deleted.
+	machine.DeletionTimestamp = new(metav1.Now())
+	require.NoError(t, r.powerCycleIfUnhealthy(ctx, rm, machine))
+	assert.Nil(t, rm.Status.LastPowerCycleTime)
+
+	machine.DeletionTimestamp = nil
+	require.NoError(t, r.powerCycleIfUnhealthy(ctx, rm, machine))
+	require.NotNil(t, rm.Status.LastPowerCycleTime)
+	// The machine is power cycled once while waiting for external remediation.
+	require.NoError(t, r.powerCycleIfUnhealthy(ctx, rm, machine))
+
+	require.NoError(t, r.powerOff(ctx, rm))
+	require.NoError(t, r.powerOff(ctx, rm))
+
+	assert.Equal(t, []string{redfishResetOn, redfishResetForceRestart, redfishResetForceOff}, server.resets)
+}
+
+func TestPooledMachineHealthCheckPoweredOff(t *testing.T) {
+	ctx := context.Background()
+	server, powerManagement, bmcSecret := newRedfishServer(t, redfishPowerOff)
+	pm, secret := winrmPooledMachine(t, &winrmServer{result: func(string) (string, int) { return "", 0 }})
+	pm.Spec.Machine.PowerManagement = powerManagement
+	c := newFakeClient(t, pm, secret, bmcSecret)
+	r := &PooledRemoteMachineController{Client: c}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pm)}
+
+	// A free machine powered off is not probed.
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.NotNil(t, pm.Status.LastProbeTime)
+	assert.Empty(t, server.resets)
+
+	// A quarantined machine is powered on to be probed once booted.
+	pm.Status.Quarantine(infrastructure.PoolQuarantineUnreachable, "connection refused")
+	pm.Status.LastProbeTime = nil
+	require.NoError(t, c.Status().Update(ctx, pm))
+	res, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, powerOnBootDelay, res.RequeueAfter)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.Nil(t, pm.Status.LastProbeTime)
+	assert.True(t, pm.Status.Quarantined)
+	assert.Equal(t, []string{redfishResetOn}, server.resets)
+
+	// Once released, the machine is powered off again.
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, pm))
+	assert.False(t, pm.Status.Quarantined)
+	assert.Equal(t, []string{redfishResetOn, redfishResetForceOff}, server.resets)
+}
+
+func TestRedfishTransport(t *testing.T) {
+	spec := &infrastructure.Redfish{Address: "https://bmc.example.com"}
+	first, err := newRedfishClient(spec, "admin", "secret", nil)
+	require.NoError(t, err)
+	second, err := newRedfishClient(spec, "root", "other", nil)
+	require.NoError(t, err)
+	assert.Same(t, first.client.Transport, second.client.Transport)
+
+	spec.Insecure = true
+	insecure, err := newRedfishClient(spec, "admin", "secret", nil)
+	require.NoError(t, err)
+	assert.NotSame(t, first.client.Transport, insecure.client.Transport)
+
+	_, err = newRedfishClient(spec, "admin", "secret", []byte("not a certificate"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+const (
+	// redfishSystemsPath is the path of the computer systems collection of a Redfish service.
+	redfishSystemsPath = "/redfish/v1/Systems"
+	// redfishTimeout is the timeout of the requests to a Redfish service.
+	redfishTimeout = 30 * time.Second
+)
+
+// Power states of a Redfish computer system.
+const (
+	redfishPowerOn  = "On"
+	redfishPowerOff = "Off"
+)
+
+// Reset types of a Redfish computer system.
+const (
+	redfishResetOn           = "On"
+	redfishResetForceOff     = "ForceOff"
+	redfishResetForceRestart = "ForceRestart"
+)
+
+// redfishTransports holds the transports of the Redfish clients by TLS settings, so that the connections are reused
+// across reconciles instead of being left open by a new transport each time.
+var redfishTransports sync.Map
+
+// redfishTransportKey identifies the TLS settings of a Redfish transport.
+type redfishTransportKey struct {
+	insecure bool
+	caCert   string
+}
+
+// redfishTransport returns the shared transport for the given TLS settings.
+func redfishTransport(insecure bool, caCert []byte) (*http.Transport, error) {
+	key := redfishTransportKey{insecure: insecure, caCert: string(caCert)}
+	if transport, ok := redfishTransports.Load(key); ok {
+		return transport.(*http.Transport), nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecure}
+	if len(caCert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse redfish CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport, _ := redfishTransports.LoadOrStore(key, &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment})
+	return transport.(*http.Transport), nil
+}
+
+// redfishClient manages the power of a computer system through a Redfish service.
+type redfishClient struct {
+	address  string
+	systemID string
+	username string
+	password string
+	client   *http.Client
+}
+
+// newRedfishClient returns a client of the Redfish service. The HTTPS certificate of the service is verified with the
+// given CA certificate, if any.
+func newRedfishClient(spec *infrastructure.Redfish, username, password string, caCert []byte) (*redfishClient, error) {
+	transport, err := redfishTransport(spec.Insecure, caCert)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redfishClient{
+		address:  strings.TrimSuffix(spec.Address, "/"),
+		systemID: spec.SystemID,
+		username: username,
+		password: password,
+		client: &http.Client{
+			Timeout:   redfishTimeout,
+			Transport: transport,
+		},
+	}, nil
+}
+
+// powerState returns the power state of the computer system.
+func (c *redfishClient) powerState(ctx context.Context) (string, error) {
+	path, err := c.systemPath(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var system struct {
+		PowerState string `json:"PowerState"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &system); err != nil {
+		return "", fmt.Errorf("failed to get redfish system: %w", err)
+	}
+	return system.PowerState, nil
+}
+
+// reset resets the computer system with the given reset type.
+func (c *redfishClient) reset(ctx context.Context, resetType string) error {
+	path, err := c.systemPath(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{"ResetType": resetType}
+	if err := c.do(ctx, http.MethodPost, path+"/Actions/ComputerSystem.Reset", body, nil); err != nil {
+		return fmt.Errorf("failed to reset redfish system with %s: %w", resetType, err)
+	}
+	return nil
+}
+
+// systemPath returns the path of the computer system, the first system of the service if the system ID is not set.
+func (c *redfishClient) systemPath(ctx context.Context) (string, error) {
+	if c.systemID != "" {
+		return redfishSystemsPath + "/" + url.PathEscape(c.systemID), nil
+	}
+
+	var systems struct {
+		Members []struct {
+			ID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := c.do(ctx, http.MethodGet, redfishSystemsPath, nil, &systems); err != nil {
+		return "", fmt.Errorf("failed to list redfish systems: %w", err)
+	}
+	if len(systems.Members) == 0 {
+		return "", errors.New("no redfish system found")
+	}
+	return systems.Members[0].ID, nil
+}
+
+// do sends the request to the Redfish service, and decodes the response into out, if not nil.
+func (c *redfishClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.address+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
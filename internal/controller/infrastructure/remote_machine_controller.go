@@ -18,18 +18,22 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"sort"
 	"time"
 
+	"github.com/k0sproject/rig/pkg/ssh/hostkey"
 	"gopkg.in/yaml.v3"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 
+	bootstrapv2 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
 	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
 	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -44,6 +48,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -89,7 +94,12 @@ const (
 // +kubebuilder:rbac:groups=exp.cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=k0sworkerconfigs;k0scontrollerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=k0sworkerconfigs/status;k0scontrollerconfigs/status,verbs=get;patch
 // +kubebuilder:rbac:groups="batch",resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 
 // Reconcile reconciles the RemoteMachine resource and ensures the remote machine is provisioned and in a ready state.
 func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -163,8 +173,12 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 	}()
 
 	// Fetch the bootstrap data
-	bootstrapData, err := r.getBootstrapData(ctx, machine)
+	bootstrapData, bootstrapFormat, err := r.getBootstrapData(ctx, machine)
 	if err != nil {
+		if apierrors.IsNotFound(err) && rm.Status.Reprovisioning && machine.ObjectMeta.DeletionTimestamp.IsZero() {
+			log.Info("Waiting for the bootstrap data to be regenerated")
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
 		// If the bootstrap data secret is not found AND the machine is being deleted, don't requeue
 		if !(apierrors.IsNotFound(err) && !machine.ObjectMeta.DeletionTimestamp.IsZero()) {
 			log.Error(err, "Failed to get bootstrap data")
@@ -172,22 +186,51 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// PowerShell bootstrap data is not cloud-init, it is run as is over WinRM.
 	cloudInit := &provisioner.InputProvisionData{}
-	err = yaml.Unmarshal(bootstrapData, cloudInit)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to parse bootstrap data: %w", err)
+	if bootstrapFormat != provisioner.PowershellProvisioningFormat && bootstrapFormat != provisioner.PowershellXMLProvisioningFormat {
+		err = yaml.Unmarshal(bootstrapData, cloudInit)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to parse bootstrap data: %w", err)
+		}
 	}
 
 	if rm.Spec.Pool != "" && rm.ObjectMeta.DeletionTimestamp.IsZero() {
-		err := r.reservePooledMachineAndPopulateRemoteMachine(ctx, rm)
+		err := r.reservePooledMachineAndPopulateRemoteMachine(ctx, rm, machine.Spec.FailureDomain)
 		if err != nil {
 			log.Error(err, "Error reserving PooledMachine")
 			return ctrl.Result{Requeue: true}, err
 		}
 	}
 
+	reporter := &bootstrapReporter{
+		machine: rm,
+		publish: func(ctx context.Context) error {
+			return rmPatchHelper.Patch(ctx, rm)
+		},
+		log: log,
+	}
+
 	var p Provisioner
-	if rm.Spec.ProvisionJob != nil {
+	if rm.Spec.Transport == infrastructure.TransportWinRM {
+		username, password, caCert, err := r.getWinRMCredentials(ctx, rm)
+		if err != nil {
+			log.Error(err, "Failed to get winrm credentials")
+			return ctrl.Result{Requeue: true}, err
+		}
+
+		p = &WinRMProvisioner{
+			bootstrapData: bootstrapData,
+			format:        bootstrapFormat,
+			cloudInit:     cloudInit,
+			machine:       rm,
+			username:      username,
+			password:      password,
+			caCert:        caCert,
+			reporter:      reporter,
+			log:           log,
+		}
+	} else if rm.Spec.ProvisionJob != nil {
 		p = &JobProvisioner{
 			bootstrapData: bootstrapData,
 			cloudInit:     cloudInit,
@@ -196,6 +239,7 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 			provisionJob:  rm.Spec.ProvisionJob,
 			client:        r.Client,
 			clientSet:     r.ClientSet,
+			reporter:      reporter,
 			log:           log,
 		}
 	} else {
@@ -206,32 +250,48 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 			return ctrl.Result{Requeue: true}, err
 		}
 
+		knownHosts, err := r.getKnownHosts(ctx, rm)
+		if err != nil {
+			log.Error(err, "Failed to get known hosts")
+			return ctrl.Result{Requeue: true}, err
+		}
+
+		bastionSSHKey, err := r.getBastionSSHKey(ctx, rm)
+		if err != nil {
+			log.Error(err, "Failed to get bastion ssh key")
+			return ctrl.Result{Requeue: true}, err
+		}
+
 		p = &SSHProvisioner{
-			cloudInit: cloudInit,
-			sshKey:    sshKey,
-			machine:   rm,
-			log:       log,
+			cloudInit:     cloudInit,
+			sshKey:        sshKey,
+			knownHosts:    knownHosts,
+			bastionSSHKey: bastionSSHKey,
+			machine:       rm,
+			reporter:      reporter,
+			log:           log,
 		}
 	}
 
 	if !rm.ObjectMeta.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(rm, RemoteMachineFinalizer) {
-			mode := ModeNonK0s
-			switch machine.Spec.Bootstrap.ConfigRef.Kind {
-			case "K0sWorkerConfig":
-				mode = ModeWorker
-			case "K0sControllerConfig":
-				mode = ModeController
-			default:
-				mode = ModeNonK0s
-			}
-
-			if err := p.Cleanup(ctx, mode); err != nil {
-				log.Error(err, "Failed to cleanup RemoteMachine")
+			cleanupErr := p.Cleanup(ctx, getMode(machine))
+			setCleanupSucceededCondition(rm, cleanupErr)
+			if cleanupErr != nil {
+				log.Error(cleanupErr, "Failed to cleanup RemoteMachine")
+				if retryCleanup(rm) {
+					return ctrl.Result{}, fmt.Errorf("failed to cleanup RemoteMachine: %w", cleanupErr)
+				}
 			}
 			if rm.Spec.Pool != "" {
-				// Return the machine back to pool
-				if err := r.returnMachineToPool(ctx, rm); err != nil {
+				// The machine is powered off before it is returned to the pool, so it does not turn off once reserved again.
+				if cleanupErr == nil {
+					if err := r.powerOff(ctx, rm); err != nil {
+						log.Error(err, "Failed to power off RemoteMachine")
+					}
+				}
+				// Return the machine back to pool, quarantined if the cleanup failed
+				if err := r.returnMachineToPool(ctx, rm, cleanupErr); err != nil {
 					return ctrl.Result{}, err
 				}
 			}
@@ -240,8 +300,21 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	if rm.ReprovisionRequested() {
+		if err := r.reprovision(ctx, rm, machine, p); err != nil {
+			log.Error(err, "Failed to reprovision RemoteMachine")
+			return ctrl.Result{}, err
+		}
+		// The machine is bootstrapped again once the bootstrap data has been regenerated.
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
 	// If the machine is already provisioned, skip reconciliation.
 	if rm.Status.Initialization.Provisioned != nil && *rm.Status.Initialization.Provisioned {
+		if err := r.powerCycleIfUnhealthy(ctx, rm, machine); err != nil {
+			log.Error(err, "Failed to power cycle RemoteMachine")
+			return ctrl.Result{}, err
+		}
 		conditions.Set(rm, metav1.Condition{
 			Type:   string(infrastructure.RemoteMachineBootstrapExecSucceededCondition),
 			Status: metav1.ConditionTrue,
@@ -249,18 +322,38 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 		})
 		return ctrl.Result{}, nil
 	}
-	if rm.Spec.ProviderID != "" {
+	if rm.Spec.ProviderID != "" && !rm.Status.Reprovisioning {
 		log.Info("RemoteMachine already has ProviderID, skipping reconciliation")
 		return ctrl.Result{}, nil
 	}
 
-	if rm.Spec.ProvisionJob == nil {
+	if rm.Spec.Transport == infrastructure.TransportWinRM {
+		if rm.Spec.Address == "" || rm.Spec.WinRM == nil {
+			rm.Status.SetFailures("MissingFields", "If pool is empty, following fields are required with the winrm transport: address, winrm")
+			return ctrl.Result{Requeue: true}, nil
+		}
+	} else if rm.Spec.ProvisionJob == nil {
 		if rm.Spec.Address == "" || rm.Spec.SSHKeyRef.Name == "" {
 			rm.Status.SetFailures("MissingFields", "If pool is empty, following fields are required: address, sshKeyRef")
 			return ctrl.Result{Requeue: true}, nil
 		}
 	}
 
+	if err := validateBootstrapFormat(rm, bootstrapFormat); err != nil {
+		rm.Status.SetFailures("UnsupportedBootstrapFormat", err.Error())
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	poweredOn, err := r.powerOn(ctx, rm)
+	if err != nil {
+		log.Error(err, "Failed to power on RemoteMachine")
+		return ctrl.Result{}, err
+	}
+	if poweredOn {
+		log.Info("Waiting for the machine to boot")
+		return ctrl.Result{RequeueAfter: powerOnBootDelay}, nil
+	}
+
 	provisionCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -289,12 +382,25 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 	}()
 
 	err = p.Provision(provisionCtx)
+	if errors.Is(err, hostkey.ErrHostKeyMismatch) {
+		conditions.Set(rm, metav1.Condition{
+			Type:    string(infrastructure.RemoteMachineBootstrapExecSucceededCondition),
+			Status:  metav1.ConditionFalse,
+			Reason:  infrastructure.RemoteMachineHostKeyMismatchReason,
+			Message: "The SSH host key of the machine is not trusted",
+		})
+		return ctrl.Result{}, fmt.Errorf("failed to provision RemoteMachine: %w", err)
+	}
 	if err != nil {
+		message := "Check the bootstrap logs for more details"
+		if step := failedBootstrapStep(rm); step != nil {
+			message = fmt.Sprintf("Bootstrap step %q failed, check status.bootstrapSteps for more details", step.Name)
+		}
 		conditions.Set(rm, metav1.Condition{
 			Type:    string(infrastructure.RemoteMachineBootstrapExecSucceededCondition),
 			Status:  metav1.ConditionFalse,
 			Reason:  infrastructure.InternalErrorReason,
-			Message: "Check the bootstrap logs for more details",
+			Message: message,
 		})
 		return ctrl.Result{}, fmt.Errorf("failed to provision RemoteMachine: %w", err)
 	}
@@ -305,6 +411,7 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 	})
 
 	rm.Spec.ProviderID = fmt.Sprintf("remote-machine://%s:%d", rm.Spec.Address, rm.Spec.Port)
+	rm.Status.Reprovisioning = false
 
 	m := machine.DeepCopy()
 	for l := range rm.Labels {
@@ -333,9 +440,131 @@ func (r *RemoteMachineController) Reconcile(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{}, nil
 }
 
+// getMode returns the mode of the machine according to its bootstrap provider.
+func getMode(machine *clusterv1.Machine) RemoteMachineMode {
+	switch machine.Spec.Bootstrap.ConfigRef.Kind {
+	case "K0sWorkerConfig":
+		return ModeWorker
+	case "K0sControllerConfig":
+		return ModeController
+	default:
+		return ModeNonK0s
+	}
+}
+
+// setCleanupSucceededCondition reports the outcome of the cleanup of the machine.
+func setCleanupSucceededCondition(rm *infrastructure.RemoteMachine, cleanupErr error) {
+	if cleanupErr != nil {
+		conditions.Set(rm, metav1.Condition{
+			Type:    infrastructure.RemoteMachineCleanupSucceededCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrastructure.RemoteMachineCleanupFailedReason,
+			Message: cleanupErr.Error(),
+		})
+		return
+	}
+	conditions.Set(rm, metav1.Condition{
+		Type:   infrastructure.RemoteMachineCleanupSucceededCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrastructure.RemoteMachineCleanupSucceededReason,
+	})
+}
+
+// retryCleanup returns true if the failed cleanup of the machine is retried, until the retry timeout of its cleanup
+// spec has passed since the cleanup first failed.
+func retryCleanup(rm *infrastructure.RemoteMachine) bool {
+	if rm.Spec.Cleanup == nil || rm.Spec.Cleanup.RetryTimeout == nil {
+		return false
+	}
+	condition := conditions.Get(rm, infrastructure.RemoteMachineCleanupSucceededCondition)
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		return false
+	}
+	return time.Since(condition.LastTransitionTime.Time) < rm.Spec.Cleanup.RetryTimeout.Duration
+}
+
+// reprovision cleans up the machine and requests the regeneration of its bootstrap data, for the machine to be
+// bootstrapped again.
+func (r *RemoteMachineController) reprovision(ctx context.Context, rm *infrastructure.RemoteMachine, machine *clusterv1.Machine, p Provisioner) error {
+	log := log.FromContext(ctx)
+	log.Info("Reprovisioning RemoteMachine")
+
+	err := p.Cleanup(ctx, getMode(machine))
+	setCleanupSucceededCondition(rm, err)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup RemoteMachine: %w", err)
+	}
+	if err := r.regenerateBootstrapData(ctx, machine); err != nil {
+		return err
+	}
+
+	delete(rm.Annotations, infrastructure.ReprovisionAnnotation)
+	rm.Status.ObservedReprovisionGeneration = rm.Spec.ReprovisionGeneration
+	rm.Status.Reprovisioning = true
+	rm.Status.Initialization.Provisioned = new(false)
+	rm.Status.CurrentStep = ""
+	rm.Status.BootstrapSteps = nil
+	conditions.Set(rm, metav1.Condition{
+		Type:    string(infrastructure.RemoteMachineBootstrapExecSucceededCondition),
+		Status:  metav1.ConditionFalse,
+		Reason:  infrastructure.RemoteMachineReprovisioningReason,
+		Message: "The machine has been cleaned up and is waiting to be bootstrapped again",
+	})
+	return nil
+}
+
+// regenerateBootstrapData deletes the bootstrap data secret of the machine and resets the status of its k0s bootstrap
+// config, for the bootstrap provider to generate the bootstrap data again. The bootstrap data of other bootstrap
+// providers is reused as is.
+func (r *RemoteMachineController) regenerateBootstrapData(ctx context.Context, machine *clusterv1.Machine) error {
+	var config client.Object
+	switch getMode(machine) {
+	case ModeWorker:
+		config = &bootstrapv2.K0sWorkerConfig{}
+	case ModeController:
+		config = &bootstrapv2.K0sControllerConfig{}
+	default:
+		return nil
+	}
+
+	// The secret is deleted first, so the bootstrap data is not found until it has been regenerated.
+	if machine.Spec.Bootstrap.DataSecretName != nil {
+		secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: *machine.Spec.Bootstrap.DataSecretName, Namespace: machine.Namespace}}
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete bootstrap data secret: %w", err)
+		}
+	}
+
+	key := client.ObjectKey{Namespace: machine.Namespace, Name: machine.Spec.Bootstrap.ConfigRef.Name}
+	if err := r.Get(ctx, key, config); err != nil {
+		return fmt.Errorf("failed to get bootstrap config: %w", err)
+	}
+	patch := client.MergeFrom(config.DeepCopyObject().(client.Object))
+	switch c := config.(type) {
+	case *bootstrapv2.K0sWorkerConfig:
+		c.Status.Initialization.DataSecretCreated = new(false)
+	case *bootstrapv2.K0sControllerConfig:
+		c.Status.Initialization.DataSecretCreated = new(false)
+	}
+	if err := r.Status().Patch(ctx, config, patch); err != nil {
+		return fmt.Errorf("failed to reset bootstrap config status: %w", err)
+	}
+	return nil
+}
+
 // reservePooledMachineAndPopulateRemoteMachine finds a free machine from the pool specified in the RemoteMachine spec, reserves it, and populates
-// the RemoteMachine spec with the details of the reserved machine.
-func (r *RemoteMachineController) reservePooledMachineAndPopulateRemoteMachine(ctx context.Context, rm *infrastructure.RemoteMachine) error {
+// the RemoteMachine spec with the details of the reserved machine. The free machine must match the pool selector of the RemoteMachine and be in
+// the given failure domain, if any.
+func (r *RemoteMachineController) reservePooledMachineAndPopulateRemoteMachine(ctx context.Context, rm *infrastructure.RemoteMachine, failureDomain string) error {
+	selector := labels.Everything()
+	if rm.Spec.PoolSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(rm.Spec.PoolSelector)
+		if err != nil {
+			return fmt.Errorf("invalid pool selector: %w", err)
+		}
+	}
+
 	pooledMachineList := &infrastructure.PooledRemoteMachineList{}
 	if err := r.Client.List(ctx, pooledMachineList, client.InNamespace(rm.Namespace)); err != nil {
 		return fmt.Errorf("failed to list pooled machines: %w", err)
@@ -357,7 +586,7 @@ func (r *RemoteMachineController) reservePooledMachineAndPopulateRemoteMachine(c
 				break
 			}
 
-			if !pm.Status.Reserved && firstFreePooledMachine == nil {
+			if !pm.Status.Reserved && !pm.Status.Quarantined && firstFreePooledMachine == nil && pooledMachineMatches(&pm, selector, failureDomain) {
 				firstFreePooledMachine = &pm
 			}
 		}
@@ -383,19 +612,49 @@ func (r *RemoteMachineController) reservePooledMachineAndPopulateRemoteMachine(c
 
 	maps.Copy(rm.Labels, foundPooledMachine.Labels)
 	maps.Copy(rm.Annotations, foundPooledMachine.Annotations)
-	rm.Spec.Address = foundPooledMachine.Spec.Machine.Address
-	rm.Spec.Port = foundPooledMachine.Spec.Machine.Port
-	rm.Spec.User = foundPooledMachine.Spec.Machine.User
-	rm.Spec.SSHKeyRef = foundPooledMachine.Spec.Machine.SSHKeyRef
-	rm.Spec.UseSudo = foundPooledMachine.Spec.Machine.UseSudo
-	rm.Spec.CommandsAsScript = foundPooledMachine.Spec.Machine.CommandsAsScript
-	rm.Spec.WorkingDir = foundPooledMachine.Spec.Machine.WorkingDir
-	rm.Spec.CleanUpCommands = foundPooledMachine.Spec.Machine.CleanUpCommands
+	populateRemoteMachineFromPool(rm, foundPooledMachine)
 
 	return nil
 }
 
-func (r *RemoteMachineController) returnMachineToPool(ctx context.Context, rm *infrastructure.RemoteMachine) error {
+// populateRemoteMachineFromPool sets the connection details of the pooled machine in the RemoteMachine spec.
+func populateRemoteMachineFromPool(rm *infrastructure.RemoteMachine, pm *infrastructure.PooledRemoteMachine) {
+	rm.Spec.FailureDomain = pm.Spec.FailureDomain
+	rm.Spec.Address = pm.Spec.Machine.Address
+	rm.Spec.Port = pm.Spec.Machine.Port
+	rm.Spec.User = pm.Spec.Machine.User
+	rm.Spec.SSHKeyRef = pm.Spec.Machine.SSHKeyRef
+	rm.Spec.UseSudo = pm.Spec.Machine.UseSudo
+	rm.Spec.CommandsAsScript = pm.Spec.Machine.CommandsAsScript
+	rm.Spec.CommandTimeout = pm.Spec.Machine.CommandTimeout
+	rm.Spec.CommandRetries = pm.Spec.Machine.CommandRetries
+	rm.Spec.WorkingDir = pm.Spec.Machine.WorkingDir
+	rm.Spec.CleanUpCommands = pm.Spec.Machine.CleanUpCommands
+	rm.Spec.Cleanup = pm.Spec.Machine.Cleanup
+	rm.Spec.HostKeyRef = pm.Spec.Machine.HostKeyRef
+	rm.Spec.HostKeyPolicy = pm.Spec.Machine.HostKeyPolicy
+	// The bastion of the template is used for the machines that do not define their own.
+	if pm.Spec.Machine.Bastion != nil {
+		rm.Spec.Bastion = pm.Spec.Machine.Bastion
+	}
+	if pm.Spec.Machine.Transport != "" {
+		rm.Spec.Transport = pm.Spec.Machine.Transport
+	}
+	rm.Spec.WinRM = pm.Spec.Machine.WinRM
+	rm.Spec.PowerManagement = pm.Spec.Machine.PowerManagement
+}
+
+// pooledMachineMatches returns whether the pooled machine matches the selector and is in the failure domain, if any.
+func pooledMachineMatches(pm *infrastructure.PooledRemoteMachine, selector labels.Selector, failureDomain string) bool {
+	if !selector.Matches(labels.Set(pm.Labels)) {
+		return false
+	}
+	return failureDomain == "" || pm.Spec.FailureDomain == failureDomain
+}
+
+// returnMachineToPool releases the pooled machine reserved by the RemoteMachine. The pooled machine is quarantined if
+// its cleanup failed.
+func (r *RemoteMachineController) returnMachineToPool(ctx context.Context, rm *infrastructure.RemoteMachine, cleanupErr error) error {
 	if rm.Spec.Pool == "" {
 		return nil
 	}
@@ -419,7 +678,11 @@ func (r *RemoteMachineController) returnMachineToPool(ctx context.Context, rm *i
 			pooledMachine.Status.MachineRef.Namespace == rm.Namespace {
 
 			pooledMachine.Status.Reserved = false
+			pooledMachine.Status.LastMachineRef = &infrastructure.RemoteMachineRef{Name: rm.Name, Namespace: rm.Namespace}
 			pooledMachine.Status.MachineRef = infrastructure.RemoteMachineRef{}
+			if cleanupErr != nil {
+				pooledMachine.Status.Quarantine(infrastructure.PoolQuarantineCleanupFailed, cleanupErr.Error())
+			}
 			if err := r.Status().Update(ctx, &pooledMachine); err != nil {
 				return fmt.Errorf("failed to update pooled machine: %w", err)
 			}
@@ -447,9 +710,69 @@ func (r *RemoteMachineController) getSSHKey(ctx context.Context, rm *infrastruct
 
 }
 
-func (r *RemoteMachineController) getBootstrapData(ctx context.Context, machine *clusterv1.Machine) ([]byte, error) {
+// getBastionSSHKey returns the SSH private key of the machine bastion, or nil if the bastion uses the key of the
+// machine.
+func (r *RemoteMachineController) getBastionSSHKey(ctx context.Context, rm *infrastructure.RemoteMachine) ([]byte, error) {
+	if rm.Spec.Bastion == nil || rm.Spec.Bastion.SSHKeyRef == nil {
+		return nil, nil
+	}
+
+	secret := &v1.Secret{}
+	key := client.ObjectKey{
+		Namespace: rm.Namespace,
+		Name:      rm.Spec.Bastion.SSHKeyRef.Name,
+	}
+	if err := r.Client.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get bastion ssh key secret: %w", err)
+	}
+	// Without the key the bastion would silently be connected to with the key of the machine.
+	sshKey, ok := secret.Data["value"]
+	if !ok || len(sshKey) == 0 {
+		return nil, fmt.Errorf("missing value key in bastion ssh key secret %s", key.Name)
+	}
+
+	return sshKey, nil
+}
+
+// getWinRMCredentials returns the user name, the password and the CA certificate, if any, used to connect to the
+// machine over WinRM.
+func (r *RemoteMachineController) getWinRMCredentials(ctx context.Context, rm *infrastructure.RemoteMachine) (string, string, []byte, error) {
+	if rm.Spec.WinRM == nil {
+		return "", "", nil, errors.New("winrm connection is not defined")
+	}
+
+	secret := &v1.Secret{}
+	key := client.ObjectKey{
+		Namespace: rm.Namespace,
+		Name:      rm.Spec.WinRM.CredentialsRef.Name,
+	}
+	if err := r.Client.Get(ctx, key, secret); err != nil {
+		return "", "", nil, fmt.Errorf("failed to get winrm credentials secret: %w", err)
+	}
+
+	username := string(secret.Data["username"])
+	if username == "" {
+		username = "Administrator"
+	}
+	return username, string(secret.Data["password"]), secret.Data["ca.crt"], nil
+}
+
+// getBootstrapData returns the bootstrap data of the machine and its format.
+// validateBootstrapFormat checks that the bootstrap data can be run on the machine. PowerShell bootstrap data is not
+// cloud-init, it can only be run over WinRM.
+func validateBootstrapFormat(rm *infrastructure.RemoteMachine, format provisioner.ProvisioningFormat) error {
+	if format != provisioner.PowershellProvisioningFormat && format != provisioner.PowershellXMLProvisioningFormat {
+		return nil
+	}
+	if rm.Spec.Transport != infrastructure.TransportWinRM {
+		return fmt.Errorf("bootstrap data format %s requires the winrm transport", format)
+	}
+	return nil
+}
+
+func (r *RemoteMachineController) getBootstrapData(ctx context.Context, machine *clusterv1.Machine) ([]byte, provisioner.ProvisioningFormat, error) {
 	if machine.Spec.Bootstrap.DataSecretName == nil {
-		return nil, fmt.Errorf("wait for bootstap secret for the machine: %s", machine.Name)
+		return nil, "", fmt.Errorf("wait for bootstap secret for the machine: %s", machine.Name)
 	}
 	secret := &v1.Secret{}
 	key := client.ObjectKey{
@@ -458,10 +781,10 @@ func (r *RemoteMachineController) getBootstrapData(ctx context.Context, machine
 	}
 
 	if err := r.SecretCachingClient.Get(ctx, key, secret); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return secret.Data["value"], nil
+	return secret.Data["value"], provisioner.ProvisioningFormat(secret.Data["format"]), nil
 }
 
 func updateStatus(ctx context.Context, rm *infrastructure.RemoteMachine, reconcileErr error) {
@@ -471,7 +794,9 @@ func updateStatus(ctx context.Context, rm *infrastructure.RemoteMachine, reconci
 	// TODO: Remove this in future when we are ready to fully remove v1beta1 conversion support.
 	rm.Status.Deprecated = nil
 
-	if reconcileErr != nil {
+	if errors.Is(reconcileErr, hostkey.ErrHostKeyMismatch) {
+		rm.Status.SetFailures(infrastructure.RemoteMachineHostKeyMismatchReason, reconcileErr.Error())
+	} else if reconcileErr != nil {
 		rm.Status.SetFailures("ProvisionFailed", reconcileErr.Error())
 	}
 
@@ -509,5 +834,8 @@ func (r *RemoteMachineController) SetupWithManager(mgr ctrl.Manager, opts contro
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&infrastructure.RemoteMachine{}).
+		// The machines with power management are power cycled when their unhealthy Machine waits for external remediation.
+		Watches(&clusterv1.Machine{},
+			handler.EnqueueRequestsFromMapFunc(capiutil.MachineToInfrastructureMapFunc(infrastructure.GroupVersion.WithKind("RemoteMachine")))).
 		Complete(r)
 }
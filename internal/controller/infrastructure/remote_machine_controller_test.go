@@ -0,0 +1,271 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bootstrapv2 "github.com/k0sproject/k0smotron/v2/api/bootstrap/v1beta2"
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+)
+
+func pooledMachine(name, pool, failureDomain string, labels map[string]string) *infrastructure.PooledRemoteMachine {
+	return &infrastructure.PooledRemoteMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: infrastructure.PooledRemoteMachineSpec{
+			Pool:          pool,
+			FailureDomain: failureDomain,
+			Machine:       infrastructure.PooledMachineSpec{Address: name + ".example.com", Port: 22},
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, infrastructure.AddToScheme(scheme))
+	require.NoError(t, bootstrapv2.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&infrastructure.PooledRemoteMachine{}, &infrastructure.RemoteCluster{}, &bootstrapv2.K0sWorkerConfig{}).
+		Build()
+}
+
+func TestReservePooledMachine(t *testing.T) {
+	c := newFakeClient(t,
+		pooledMachine("a-cpu", "workers", "rack-a", nil),
+		pooledMachine("b-cpu", "workers", "rack-b", nil),
+		pooledMachine("b-gpu", "workers", "rack-b", map[string]string{"gpu": "true"}),
+		pooledMachine("other", "other", "rack-b", map[string]string{"gpu": "true"}),
+	)
+	r := &RemoteMachineController{Client: c}
+	ctx := context.Background()
+
+	newRemoteMachine := func(name string) *infrastructure.RemoteMachine {
+		return &infrastructure.RemoteMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Labels:      map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+				Annotations: map[string]string{},
+			},
+			Spec: infrastructure.RemoteMachineSpec{Pool: "workers"},
+		}
+	}
+
+	gpu := newRemoteMachine("gpu")
+	gpu.Spec.PoolSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"gpu": "true"}}
+	require.NoError(t, r.reservePooledMachineAndPopulateRemoteMachine(ctx, gpu, ""))
+	assert.Equal(t, "b-gpu.example.com", gpu.Spec.Address)
+	assert.Equal(t, "rack-b", gpu.Spec.FailureDomain)
+
+	// The machine reserved for the RemoteMachine is found again.
+	require.NoError(t, r.reservePooledMachineAndPopulateRemoteMachine(ctx, gpu, ""))
+	assert.Equal(t, "b-gpu.example.com", gpu.Spec.Address)
+
+	rackB := newRemoteMachine("rack-b")
+	require.NoError(t, r.reservePooledMachineAndPopulateRemoteMachine(ctx, rackB, "rack-b"))
+	assert.Equal(t, "b-cpu.example.com", rackB.Spec.Address)
+
+	assert.ErrorIs(t, r.reservePooledMachineAndPopulateRemoteMachine(ctx, newRemoteMachine("rack-b-2"), "rack-b"), ErrPooledMachineNotFound)
+
+	pm := &infrastructure.PooledRemoteMachine{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b-gpu"}, pm))
+	assert.True(t, pm.Status.Reserved)
+	assert.Equal(t, "gpu", pm.Status.MachineRef.Name)
+}
+
+func TestRemoteClusterFailureDomains(t *testing.T) {
+	rc := &infrastructure.RemoteCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+	c := newFakeClient(t,
+		rc,
+		pooledMachine("a", "controllers", "rack-a", nil),
+		pooledMachine("b", "controllers", "rack-b", nil),
+		pooledMachine("c", "workers", "rack-c", nil),
+		pooledMachine("d", "workers", "", nil),
+	)
+	r := &ClusterController{Client: c}
+	ctx := context.Background()
+
+	failureDomains, err := r.failureDomains(ctx, rc)
+	require.NoError(t, err)
+	assert.Equal(t, []clusterv1.FailureDomain{
+		{Name: "rack-a", ControlPlane: new(true)},
+		{Name: "rack-b", ControlPlane: new(true)},
+		{Name: "rack-c", ControlPlane: new(true)},
+	}, failureDomains)
+
+	rc.Spec.Pools = []string{"controllers"}
+	failureDomains, err = r.failureDomains(ctx, rc)
+	require.NoError(t, err)
+	require.Len(t, failureDomains, 2)
+	assert.Equal(t, "rack-b", failureDomains[1].Name)
+}
+
+func TestReturnMachineToPoolQuarantine(t *testing.T) {
+	ctx := context.Background()
+	reserved := pooledMachine("reserved", "workers", "", nil)
+	reserved.Status.Reserved = true
+	reserved.Status.MachineRef = infrastructure.RemoteMachineRef{Name: "rm", Namespace: "default"}
+	c := newFakeClient(t, reserved)
+	r := &RemoteMachineController{Client: c}
+
+	rm := &infrastructure.RemoteMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "rm", Namespace: "default", Labels: map[string]string{}, Annotations: map[string]string{}},
+		Spec:       infrastructure.RemoteMachineSpec{Pool: "workers"},
+	}
+	require.NoError(t, r.returnMachineToPool(ctx, rm, errors.New(`command "k0s reset" failed`)))
+
+	pm := &infrastructure.PooledRemoteMachine{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "reserved"}, pm))
+	assert.False(t, pm.Status.Reserved)
+	assert.Equal(t, &infrastructure.RemoteMachineRef{Name: "rm", Namespace: "default"}, pm.Status.LastMachineRef)
+	assert.True(t, pm.Status.Quarantined)
+	assert.Equal(t, infrastructure.PoolQuarantineCleanupFailed, pm.Status.QuarantineReason)
+
+	// The quarantined machine is not reserved again.
+	assert.ErrorIs(t, r.reservePooledMachineAndPopulateRemoteMachine(ctx, rm, ""), ErrPooledMachineNotFound)
+
+	pm.Status.Release()
+	require.NoError(t, c.Status().Update(ctx, pm))
+	require.NoError(t, r.reservePooledMachineAndPopulateRemoteMachine(ctx, rm, ""))
+	assert.Equal(t, "reserved.example.com", rm.Spec.Address)
+}
+
+// fakeProvisioner records the cleanups of the machine.
+type fakeProvisioner struct {
+	cleanups []RemoteMachineMode
+}
+
+func (p *fakeProvisioner) Provision(context.Context) error {
+	return nil
+}
+
+func (p *fakeProvisioner) Cleanup(_ context.Context, mode RemoteMachineMode) error {
+	p.cleanups = append(p.cleanups, mode)
+	return nil
+}
+
+func TestReprovision(t *testing.T) {
+	ctx := context.Background()
+	config := &bootstrapv2.K0sWorkerConfig{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"}}
+	config.Status.Initialization.DataSecretCreated = new(true)
+	config.Status.DataSecretName = new("worker")
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"}}
+	c := newFakeClient(t, config, secret)
+	r := &RemoteMachineController{Client: c}
+
+	machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"}}
+	machine.Spec.Bootstrap.ConfigRef = clusterv1.ContractVersionedObjectReference{Kind: "K0sWorkerConfig", Name: "worker"}
+	machine.Spec.Bootstrap.DataSecretName = new("worker")
+
+	rm := &infrastructure.RemoteMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default", Annotations: map[string]string{infrastructure.ReprovisionAnnotation: ""}},
+		Spec:       infrastructure.RemoteMachineSpec{ProviderID: "remote-machine://1.2.3.4:22"},
+	}
+	rm.Status.Initialization.Provisioned = new(true)
+	rm.Status.BootstrapSteps = []infrastructure.BootstrapStep{{Name: "k0s install worker", Phase: infrastructure.BootstrapStepFailed}}
+	require.True(t, rm.ReprovisionRequested())
+
+	p := &fakeProvisioner{}
+	require.NoError(t, r.reprovision(ctx, rm, machine, p))
+	assert.Equal(t, []RemoteMachineMode{ModeWorker}, p.cleanups)
+	assert.False(t, rm.ReprovisionRequested())
+	assert.True(t, rm.Status.Reprovisioning)
+	assert.False(t, *rm.Status.Initialization.Provisioned)
+	assert.Empty(t, rm.Status.BootstrapSteps)
+
+	// The bootstrap data is regenerated by the bootstrap controller.
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(secret), &v1.Secret{})))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(config), config))
+	assert.False(t, *config.Status.Initialization.DataSecretCreated)
+
+	rm.Spec.ReprovisionGeneration = 1
+	assert.True(t, rm.ReprovisionRequested())
+	require.NoError(t, r.reprovision(ctx, rm, machine, p))
+	assert.Equal(t, int64(1), rm.Status.ObservedReprovisionGeneration)
+	assert.False(t, rm.ReprovisionRequested())
+}
+
+func TestRetryCleanup(t *testing.T) {
+	rm := &infrastructure.RemoteMachine{}
+	setCleanupSucceededCondition(rm, errors.New("k0s reset failed"))
+	assert.False(t, retryCleanup(rm))
+
+	rm.Spec.Cleanup = &infrastructure.Cleanup{RetryTimeout: &metav1.Duration{Duration: time.Minute}}
+	assert.True(t, retryCleanup(rm))
+
+	// The retry timeout starts when the cleanup first fails.
+	rm.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	setCleanupSucceededCondition(rm, errors.New("k0s reset failed"))
+	assert.False(t, retryCleanup(rm))
+
+	setCleanupSucceededCondition(rm, nil)
+	assert.False(t, retryCleanup(rm))
+	assert.Equal(t, infrastructure.RemoteMachineCleanupSucceededReason, rm.Status.Conditions[0].Reason)
+}
+
+func TestGetBastionSSHKey(t *testing.T) {
+	ctx := context.Background()
+	r := &RemoteMachineController{Client: newFakeClient(t,
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bastion", Namespace: "default"}, Data: map[string][]byte{"value": []byte("key")}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default"}},
+	)}
+	rm := &infrastructure.RemoteMachine{ObjectMeta: metav1.ObjectMeta{Name: "rm", Namespace: "default"}}
+
+	// The key of the machine is used when the bastion has none.
+	key, err := r.getBastionSSHKey(ctx, rm)
+	require.NoError(t, err)
+	assert.Nil(t, key)
+
+	rm.Spec.Bastion = &infrastructure.Bastion{Address: "bastion.example.com", SSHKeyRef: &infrastructure.SecretRef{Name: "bastion"}}
+	key, err = r.getBastionSSHKey(ctx, rm)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), key)
+
+	rm.Spec.Bastion.SSHKeyRef.Name = "empty"
+	_, err = r.getBastionSSHKey(ctx, rm)
+	assert.Error(t, err)
+}
+
+func TestValidateBootstrapFormat(t *testing.T) {
+	rm := &infrastructure.RemoteMachine{}
+	require.NoError(t, validateBootstrapFormat(rm, provisioner.CloudInitProvisioningFormat))
+	require.Error(t, validateBootstrapFormat(rm, provisioner.PowershellProvisioningFormat))
+	require.Error(t, validateBootstrapFormat(rm, provisioner.PowershellXMLProvisioningFormat))
+
+	rm.Spec.Transport = infrastructure.TransportWinRM
+	require.NoError(t, validateBootstrapFormat(rm, provisioner.PowershellProvisioningFormat))
+}
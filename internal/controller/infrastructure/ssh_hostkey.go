@@ -0,0 +1,255 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/rig"
+	"github.com/k0sproject/rig/pkg/ssh/hostkey"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+const hostKeyScanTimeout = 30 * time.Second
+
+// errHostKeyScanned aborts the SSH handshake once the host key has been received.
+var errHostKeyScanned = errors.New("host key scanned")
+
+// dialFunc opens a network connection to the given address.
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// trustedHostKey verifies the SSH host key of the given host, either the machine or its bastion, according to the
+// host key policy of the machine and returns the key the connection must be pinned to. An empty key is returned with
+// the Insecure policy. With the TrustOnFirstUse policy, the key accepted on the first connection is stored in
+// recordedKey, which points to the machine status.
+func (p *SSHProvisioner) trustedHostKey(ctx context.Context, dial dialFunc, address string, port int, recordedKey *string) (string, error) {
+	policy := p.machine.Spec.GetHostKeyPolicy()
+	if policy == infrastructure.HostKeyPolicyInsecure {
+		return "", nil
+	}
+
+	var (
+		callback ssh.HostKeyCallback
+		err      error
+	)
+	switch {
+	case policy == infrastructure.HostKeyPolicyTrustOnFirstUse && *recordedKey != "":
+		callback = hostkey.StaticKeyCallback(*recordedKey)
+	case len(p.knownHosts) > 0:
+		callback, err = knownHostsCallback(p.knownHosts)
+		if err != nil {
+			return "", err
+		}
+	case policy == infrastructure.HostKeyPolicyStrict:
+		return "", fmt.Errorf("%w: no known host keys for the Strict host key policy", hostkey.ErrHostKeyMismatch)
+	}
+
+	key, err := scanHostKey(ctx, dial, address, port, callback)
+	if err != nil {
+		return "", err
+	}
+
+	if policy == infrastructure.HostKeyPolicyTrustOnFirstUse && *recordedKey == "" {
+		p.log.Info("Trusting host key on first use", "address", address, "fingerprint", ssh.FingerprintSHA256(key))
+		*recordedKey = authorizedKey(key)
+	}
+
+	return authorizedKey(key), nil
+}
+
+// scanHostKey opens an SSH connection to the host using the given dial function and returns the host key it presents,
+// verified with the given callback if not nil. The handshake is aborted before authenticating. The connection uses the
+// default host key algorithms, so the server presents the same key as on the provisioning connection.
+func scanHostKey(ctx context.Context, dial dialFunc, address string, port int, callback ssh.HostKeyCallback) (ssh.PublicKey, error) {
+	addr := net.JoinHostPort(address, strconv.Itoa(port))
+
+	var (
+		hostKey   ssh.PublicKey
+		verifyErr error
+	)
+	config := &ssh.ClientConfig{
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if callback != nil {
+				if err := callback(hostname, remote, key); err != nil {
+					verifyErr = err
+					return err
+				}
+			}
+			hostKey = key
+			return errHostKeyScanned
+		},
+		Timeout: hostKeyScanTimeout,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hostKeyScanTimeout)
+	defer cancel()
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to host: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(hostKeyScanTimeout))
+
+	_, _, _, err = ssh.NewClientConn(conn, addr, config)
+	if verifyErr != nil {
+		if errors.Is(verifyErr, hostkey.ErrHostKeyMismatch) {
+			return nil, verifyErr
+		}
+		return nil, fmt.Errorf("%w: %w", hostkey.ErrHostKeyMismatch, verifyErr)
+	}
+	if hostKey == nil {
+		return nil, fmt.Errorf("failed to get host key: %w", err)
+	}
+
+	return hostKey, nil
+}
+
+// dialBastion opens an SSH connection to the bastion, pinned to its verified host key if any. The connection is used
+// to reach the machine while verifying its host key.
+func dialBastion(ctx context.Context, bastion *rig.SSH) (*ssh.Client, error) {
+	// The bastion host key is only empty with the Insecure host key policy.
+	callback := ssh.InsecureIgnoreHostKey()
+	if bastion.HostKey != "" {
+		callback = hostkey.StaticKeyCallback(bastion.HostKey)
+	}
+	config := &ssh.ClientConfig{
+		User:            bastion.User,
+		Auth:            bastion.AuthMethods,
+		HostKeyCallback: callback,
+		Timeout:         hostKeyScanTimeout,
+	}
+
+	addr := net.JoinHostPort(bastion.Address, strconv.Itoa(bastion.Port))
+	dialer := net.Dialer{Timeout: hostKeyScanTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bastion: %w", err)
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to connect to bastion: %w", err)
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// knownHostsCallback returns a callback accepting the host keys listed in the given known_hosts lines.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	// knownhosts only reads files, the content is parsed when the callback is created.
+	f, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create known_hosts file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(knownHosts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write known_hosts file: %w", err)
+	}
+
+	callback, err := knownhosts.New(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse known_hosts: %w", err)
+	}
+	return callback, nil
+}
+
+// authorizedKey returns the key in the authorized_keys format, as expected by rig.
+func authorizedKey(key ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+// getKnownHosts returns the known_hosts lines referenced by the machine, if any.
+func (r *RemoteMachineController) getKnownHosts(ctx context.Context, rm *infrastructure.RemoteMachine) ([]byte, error) {
+	ref := rm.Spec.HostKeyRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	key := client.ObjectKey{Namespace: rm.Namespace, Name: ref.Name}
+	switch ref.Kind {
+	case "ConfigMap":
+		cm := &v1.ConfigMap{}
+		if err := r.Client.Get(ctx, key, cm); err != nil {
+			return nil, fmt.Errorf("failed to get host key configmap: %w", err)
+		}
+		knownHosts, ok := cm.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("host key configmap %s does not contain key %s", ref.Name, ref.Key)
+		}
+		return []byte(knownHosts), nil
+	default:
+		secret := &v1.Secret{}
+		if err := r.Client.Get(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("failed to get host key secret: %w", err)
+		}
+		knownHosts, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("host key secret %s does not contain key %s", ref.Name, ref.Key)
+		}
+		return knownHosts, nil
+	}
+}
+
+// setHostKeyVerifiedCondition reports the result of the host key verification. Errors other than a host key mismatch
+// do not change the condition.
+func setHostKeyVerifiedCondition(rm *infrastructure.RemoteMachine, err error) {
+	if err != nil && !errors.Is(err, hostkey.ErrHostKeyMismatch) {
+		return
+	}
+
+	switch {
+	case err != nil:
+		conditions.Set(rm, metav1.Condition{
+			Type:    infrastructure.RemoteMachineHostKeyVerifiedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrastructure.RemoteMachineHostKeyMismatchReason,
+			Message: err.Error(),
+		})
+	case rm.Spec.GetHostKeyPolicy() == infrastructure.HostKeyPolicyInsecure:
+		conditions.Set(rm, metav1.Condition{
+			Type:    infrastructure.RemoteMachineHostKeyVerifiedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrastructure.RemoteMachineHostKeyVerificationDisabledReason,
+			Message: "Host key verification is disabled by the Insecure host key policy",
+		})
+	default:
+		conditions.Set(rm, metav1.Condition{
+			Type:   infrastructure.RemoteMachineHostKeyVerifiedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrastructure.RemoteMachineHostKeyVerifiedReason,
+		})
+	}
+}
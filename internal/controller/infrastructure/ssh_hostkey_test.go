@@ -0,0 +1,236 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/k0sproject/rig/pkg/ssh/hostkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+// startSSHServer starts an SSH server accepting any client and forwarding its direct-tcpip channels, and returns its
+// address and host key.
+func startSSHServer(t *testing.T) (*net.TCPAddr, ssh.PublicKey) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, chans, reqs, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(reqs)
+				for newChannel := range chans {
+					go forwardChannel(newChannel)
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().(*net.TCPAddr), signer.PublicKey()
+}
+
+// forwardChannel forwards a direct-tcpip channel to its destination, as a bastion does.
+func forwardChannel(newChannel ssh.NewChannel) {
+	var payload struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &payload) != nil {
+		_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel")
+		return
+	}
+	dst, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	channel, reqs, err := newChannel.Accept()
+	if err != nil {
+		_ = dst.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	go func() {
+		_, _ = io.Copy(channel, dst)
+		_ = channel.Close()
+	}()
+	_, _ = io.Copy(dst, channel)
+	_ = dst.Close()
+}
+
+func TestTrustedHostKey(t *testing.T) {
+	addr, key := startSSHServer(t)
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherSigner, err := ssh.NewSignerFromKey(otherPriv)
+	require.NoError(t, err)
+	otherKey := otherSigner.PublicKey()
+
+	knownHostsLine := func(k ssh.PublicKey) []byte {
+		return []byte(knownhosts.Line([]string{knownhosts.Normalize(addr.String())}, k) + "\n")
+	}
+
+	tests := []struct {
+		name          string
+		policy        infrastructure.HostKeyPolicy
+		hostKeyRef    bool
+		knownHosts    []byte
+		statusHostKey string
+		want          string
+		wantStatus    string
+		wantMismatch  bool
+	}{
+		{
+			name:   "insecure",
+			policy: infrastructure.HostKeyPolicyInsecure,
+		},
+		{
+			name:       "trust on first use",
+			want:       authorizedKey(key),
+			wantStatus: authorizedKey(key),
+		},
+		{
+			name:          "trust on first use with known key",
+			statusHostKey: authorizedKey(key),
+			want:          authorizedKey(key),
+			wantStatus:    authorizedKey(key),
+		},
+		{
+			name:          "trust on first use with changed key",
+			statusHostKey: authorizedKey(otherKey),
+			wantStatus:    authorizedKey(otherKey),
+			wantMismatch:  true,
+		},
+		{
+			name:       "strict",
+			hostKeyRef: true,
+			knownHosts: knownHostsLine(key),
+			want:       authorizedKey(key),
+		},
+		{
+			name:         "strict with unknown key",
+			hostKeyRef:   true,
+			knownHosts:   knownHostsLine(otherKey),
+			wantMismatch: true,
+		},
+		{
+			name:         "strict without known hosts",
+			policy:       infrastructure.HostKeyPolicyStrict,
+			wantMismatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := &infrastructure.RemoteMachine{
+				Spec: infrastructure.RemoteMachineSpec{
+					Address:       addr.IP.String(),
+					Port:          addr.Port,
+					HostKeyPolicy: tt.policy,
+				},
+				Status: infrastructure.RemoteMachineStatus{HostKey: tt.statusHostKey},
+			}
+			if tt.hostKeyRef {
+				rm.Spec.HostKeyRef = &infrastructure.HostKeyRef{Name: "known-hosts"}
+			}
+			p := &SSHProvisioner{machine: rm, knownHosts: tt.knownHosts, log: logr.Discard()}
+
+			dialer := &net.Dialer{}
+			got, err := p.trustedHostKey(context.Background(), dialer.DialContext, addr.IP.String(), addr.Port, &rm.Status.HostKey)
+			setHostKeyVerifiedCondition(rm, err)
+			if tt.wantMismatch {
+				require.ErrorIs(t, err, hostkey.ErrHostKeyMismatch)
+				assert.Equal(t, infrastructure.RemoteMachineHostKeyMismatchReason, conditions.GetReason(rm, infrastructure.RemoteMachineHostKeyVerifiedCondition))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantStatus, rm.Status.HostKey)
+		})
+	}
+}
+
+func TestTrustedHostKeyThroughBastion(t *testing.T) {
+	bastionAddr, bastionKey := startSSHServer(t)
+	addr, key := startSSHServer(t)
+
+	rm := &infrastructure.RemoteMachine{
+		Spec: infrastructure.RemoteMachineSpec{
+			Address: addr.IP.String(),
+			Port:    addr.Port,
+			Bastion: &infrastructure.Bastion{
+				Address: bastionAddr.IP.String(),
+				Port:    bastionAddr.Port,
+				User:    "root",
+			},
+		},
+	}
+	p := &SSHProvisioner{machine: rm, log: logr.Discard()}
+
+	bastion, err := p.bastion(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, authorizedKey(bastionKey), bastion.HostKey)
+	assert.Equal(t, authorizedKey(bastionKey), rm.Status.BastionHostKey)
+
+	bastionClient, err := dialBastion(context.Background(), bastion)
+	require.NoError(t, err)
+	defer bastionClient.Close()
+
+	got, err := p.trustedHostKey(context.Background(), bastionClient.DialContext, rm.Spec.Address, rm.Spec.Port, &rm.Status.HostKey)
+	require.NoError(t, err)
+	assert.Equal(t, authorizedKey(key), got)
+	assert.Equal(t, authorizedKey(key), rm.Status.HostKey)
+
+	// The bastion presenting another key than the recorded one is a mismatch.
+	rm.Status.BastionHostKey = authorizedKey(key)
+	_, err = p.bastion(context.Background(), nil)
+	require.ErrorIs(t, err, hostkey.ErrHostKeyMismatch)
+}
@@ -22,16 +22,20 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
+	"al.essio.dev/pkg/shellescape"
 	"github.com/go-logr/logr"
 	"github.com/k0sproject/rig"
 	"github.com/k0sproject/rig/exec"
 	"github.com/k0sproject/rig/pkg/rigfs"
 	"github.com/k0sproject/rig/pkg/ssh/hostkey"
+	"golang.org/x/crypto/ssh"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
@@ -41,9 +45,8 @@ import (
 var regex = regexp.MustCompile(`--kubelet-root-dir[ =](/[/a-zA-Z0-9_-]+)+`)
 
 func init() {
-	// RemoteMachine manages infrastructure where VMs may be reprovisioned with
-	// new host keys at the same address. Disable host key verification to avoid
-	// persistent connection failures after a wipe-and-reprovision cycle.
+	// Host keys are verified per machine according to its host key policy, and the connections are pinned to the
+	// verified key. The controller must not read nor write a known_hosts file, this only applies to the Insecure policy.
 	hostkey.KnownHostsPathFromEnv = func() (string, bool) {
 		return "/dev/null", true
 	}
@@ -54,7 +57,12 @@ type SSHProvisioner struct {
 	cloudInit *provisioner.InputProvisionData
 	machine   *api.RemoteMachine
 	sshKey    []byte
-	log       logr.Logger
+	// knownHosts holds the known_hosts lines referenced by the machine hostKeyRef.
+	knownHosts []byte
+	// bastionSSHKey holds the SSH private key of the bastion, if it does not use the key of the machine.
+	bastionSSHKey []byte
+	reporter      *bootstrapReporter
+	log           logr.Logger
 }
 
 const stopCommandTemplate = `(command -v systemctl > /dev/null 2>&1 && systemctl stop %s) || ` + // systemd
@@ -62,6 +70,9 @@ const stopCommandTemplate = `(command -v systemctl > /dev/null 2>&1 && systemctl
 	`(command -v service > /dev/null 2>&1 && service %s stop) || ` + // SysV
 	`(echo "Not a supported init system"; false)`
 
+// rebootCommand reboots the machine once the command has returned, so the SSH session is not torn down while it runs.
+const rebootCommand = `nohup sh -c 'sleep 2; reboot' > /dev/null 2>&1 &`
+
 const (
 	ctrlService   = "k0scontroller"
 	workerService = "k0sworker"
@@ -70,42 +81,21 @@ const (
 // Provision provisions a new machine
 // The provisioning process is as follows:
 // 1. Open SSH connection to the machine
-// 2. Execute the bootstrap script
+// 2. Upload the files and execute the bootstrap commands, skipping the ones completed by a previous attempt
 // 3. Check sentinel file at /run/cluster-api/bootstrap-success.complete
 // 4. success
 func (p *SSHProvisioner) Provision(ctx context.Context) error {
 	log := log.FromContext(ctx).WithValues("remotemachine", p.machine.Name)
 
-	authM, err := rig.ParseSSHPrivateKey(p.sshKey, nil)
+	session, err := p.openSession(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to parse ssh key: %w", err)
-	}
-
-	connection := &rig.Connection{
-		SSH: &rig.SSH{
-			Address:     p.machine.Spec.Address,
-			Port:        p.machine.Spec.Port,
-			User:        p.machine.Spec.User,
-			AuthMethods: authM,
-		},
-	}
-
-	if err := connection.Connect(); err != nil {
-		return fmt.Errorf("failed to connect to host: %w", err)
-	}
-	defer connection.Disconnect()
-
-	// If sudo is required, run commands with elevated permissions and use the
-	// sudo-capable filesystem for uploads.
-	var execOpts []exec.Option
-	fsys := connection.Fsys()
-	if p.machine.Spec.UseSudo {
-		execOpts = append(execOpts, exec.Sudo(connection))
-		fsys = connection.SudoFsys()
+		return err
 	}
+	defer func() { session.connection.Disconnect() }()
 
+	commands := p.cloudInit.Commands
 	if p.machine.Spec.CommandsAsScript {
-		// Write the bootstrap script
+		// Write the bootstrap script, which is run instead of the commands
 		installScriptPath := filepath.Join(p.machine.Spec.WorkingDir, "k0s_install.sh")
 		bootstrapFile := provisioner.File{
 			Path:        installScriptPath,
@@ -113,42 +103,45 @@ func (p *SSHProvisioner) Provision(ctx context.Context) error {
 			Content:     "#!/bin/sh\nset -e\n\n" + strings.Join(p.cloudInit.Commands, "\n") + "\n",
 		}
 		p.cloudInit.Files = append(p.cloudInit.Files, bootstrapFile)
+		commands = []string{installScriptPath}
 	}
+	p.reporter.init(ctx, bootstrapStepNames(p.cloudInit.Files, commands))
+
+	// Resume after the steps completed by a previous attempt
+	resume := true
 
 	// Write files first
-	for _, file := range p.cloudInit.Files {
+	for i, file := range p.cloudInit.Files {
 		p.log.Info("Uploading file", "path", file.Path, "permissions", file.Permissions)
-		if err := p.uploadFile(fsys, file); err != nil {
+		checkpoint := p.checkpointPath(i, file.Path+"\n"+file.Permissions+"\n"+file.Content)
+		err := p.runStep(ctx, session, i, checkpoint, &resume, func() (string, error) {
+			return "", p.uploadFile(session.fsys, file)
+		})
+		if err != nil {
 			return fmt.Errorf("failed to upload file: %w", err)
 		}
 		p.log.Info("Uploaded file", "path", file.Path, "permissions", file.Permissions)
 	}
 
-	if p.machine.Spec.CommandsAsScript {
-		// Run the install script
-		installScriptPath := filepath.Join(p.machine.Spec.WorkingDir, "k0s_install.sh")
-		p.log.Info("running install script", "command", installScriptPath)
-		output, err := connection.ExecOutput(installScriptPath, execOpts...)
+	// Run commands, or the install script
+	for i, cmd := range commands {
+		step := len(p.cloudInit.Files) + i
+		p.log.Info("running command", "command", cmd)
+		var output string
+		err := p.runStep(ctx, session, step, p.checkpointPath(step, cmd), &resume, func() (string, error) {
+			var err error
+			output, err = p.exec(ctx, session, cmd)
+			return output, err
+		})
 		if err != nil {
-			p.log.Error(err, "failed to run command", "command", installScriptPath, "output", output)
+			p.log.Error(err, "failed to run command", "command", cmd, "output", output)
 			return fmt.Errorf("failed to run command: %w", err)
 		}
-		log.Info("executed install script", "command", installScriptPath, "output", output)
-	} else {
-		// Run commands
-		for _, cmd := range p.cloudInit.Commands {
-			p.log.Info("running command", "command", cmd)
-			output, err := connection.ExecOutput(cmd, execOpts...)
-			if err != nil {
-				p.log.Error(err, "failed to run command", "command", cmd, "output", output)
-				return fmt.Errorf("failed to run command: %w", err)
-			}
-			log.Info("executed command", "command", cmd, "output", output)
-		}
+		log.Info("executed command", "command", cmd, "output", output)
 	}
 
 	// Check for sentinel file
-	if _, err := connection.SudoFsys().Stat("/run/cluster-api/bootstrap-success.complete"); err != nil {
+	if _, err := session.connection.SudoFsys().Stat("/run/cluster-api/bootstrap-success.complete"); err != nil {
 		return errors.New("bootstrap sentinel file not found")
 	}
 
@@ -160,45 +153,43 @@ func (p *SSHProvisioner) Provision(ctx context.Context) error {
 // 1. Open SSH connection to the machine
 // 2. Stops k0s
 // 3. Removes node from etcd
-// 4. Runs k0s reset
-func (p *SSHProvisioner) Cleanup(_ context.Context, mode RemoteMachineMode) error {
-	authM, err := rig.ParseSSHPrivateKey(p.sshKey, nil)
+// 4. Runs the pre-reset commands and k0s reset
+// 5. Removes the extra paths and reboots, if requested
+func (p *SSHProvisioner) Cleanup(ctx context.Context, mode RemoteMachineMode) error {
+	connection, err := p.connect(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to parse ssh key: %w", err)
-	}
-
-	connection := &rig.Connection{
-		SSH: &rig.SSH{
-			Address:     p.machine.Spec.Address,
-			Port:        p.machine.Spec.Port,
-			User:        p.machine.Spec.User,
-			AuthMethods: authM,
-		},
-	}
-
-	if err := connection.Connect(); err != nil {
-		return fmt.Errorf("failed to connect to host: %w", err)
+		return err
 	}
 	defer connection.Disconnect()
 
 	// If sudo is required, run commands with elevated permissions.
 	var execOpts []exec.Option
+	fsys := connection.Fsys()
 	if p.machine.Spec.UseSudo {
 		execOpts = append(execOpts, exec.Sudo(connection))
+		fsys = connection.SudoFsys()
 	}
 
+	if err := fsys.RemoveAll(bootstrapCheckpointDir); err != nil {
+		p.log.Error(err, "failed to remove bootstrap checkpoints", "path", bootstrapCheckpointDir)
+	}
+
+	// The cleanup goes on when a command fails, the errors are reported once done.
+	var errs []error
+
 	if p.machine.Spec.CleanUpCommands != nil {
 		p.log.Info("Cleaning up remote machine...")
 		for _, cmd := range p.machine.Spec.CleanUpCommands {
 			output, err := connection.ExecOutput(cmd, execOpts...)
 			if err != nil {
 				p.log.Error(err, "failed to run command", "command", cmd, "output", output)
+				errs = append(errs, fmt.Errorf("command %q failed: %w", cmd, err))
 			} else {
 				p.log.Info("executed command", "command", cmd, "output", output)
 			}
 		}
 
-		return nil
+		return errors.Join(errs...)
 	}
 
 	if mode == ModeNonK0s {
@@ -208,51 +199,158 @@ func (p *SSHProvisioner) Cleanup(_ context.Context, mode RemoteMachineMode) erro
 	}
 
 	// k0s bootstrap provider used.
-	var cmds []string
-	if mode == ModeController {
-		cmds = append(cmds, "k0s etcd leave")
-		cmds = append(cmds, fmt.Sprintf(stopCommandTemplate, ctrlService, ctrlService, ctrlService))
-	} else {
-		cmds = append(cmds, fmt.Sprintf(stopCommandTemplate, workerService, workerService, ctrlService))
-	}
-
-	var kubeletRootDir string
-	for _, cmd := range p.cloudInit.Commands {
-		if strings.Contains(cmd, "--kubelet-root-dir") {
-			finds := regex.FindStringSubmatch(cmd)
-			if len(finds) > 1 {
-				kubeletRootDir = finds[1]
-				break
-			}
-		}
-	}
-	if kubeletRootDir == "" {
-		cmds = append(cmds, "k0s reset")
-	} else {
-		cmds = append(cmds, "k0s reset --kubelet-root-dir "+kubeletRootDir)
-	}
-
 	p.log.Info("Cleaning up remote machine...")
-	for _, cmd := range cmds {
+	for _, cmd := range p.cleanupCommands(mode) {
 		output, err := connection.ExecOutput(cmd, execOpts...)
 		if err != nil {
+			p.log.Error(err, "failed to run command", "command", cmd, "output", output)
 			// if k0s command is not installed, manually remove files added for k0s bootstrap.
 			if strings.Contains(err.Error(), "command not found") {
 				for _, file := range p.cloudInit.Files {
 					p.log.Info("Removing file", "path", file.Path)
 					err := connection.SudoFsys().Remove(file.Path)
-					if err != nil {
+					if err != nil && !errors.Is(err, fs.ErrNotExist) {
 						p.log.Error(err, "failed to remove file", "path", file.Path)
+						errs = append(errs, fmt.Errorf("failed to remove file %s: %w", file.Path, err))
 					} else {
 						p.log.Info("Removed file", "path", file.Path)
 					}
 				}
+				continue
 			}
-			p.log.Error(err, "failed to run command", "command", cmd, "output", output)
+			errs = append(errs, fmt.Errorf("command %q failed: %w", cmd, err))
 		}
 	}
 
-	return nil
+	if len(errs) == 0 && p.machine.Spec.Cleanup != nil && p.machine.Spec.Cleanup.Reboot {
+		p.log.Info("Rebooting remote machine")
+		if output, err := connection.ExecOutput(rebootCommand, execOpts...); err != nil {
+			p.log.Error(err, "failed to reboot", "output", output)
+			errs = append(errs, fmt.Errorf("failed to reboot: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// cleanupCommands returns the commands removing the k0s installation of the machine, customized by its cleanup spec.
+func (p *SSHProvisioner) cleanupCommands(mode RemoteMachineMode) []string {
+	cleanup := p.machine.Spec.Cleanup
+	if cleanup == nil {
+		cleanup = &api.Cleanup{}
+	}
+
+	var cmds []string
+	if mode == ModeController {
+		cmds = append(cmds, "k0s etcd leave")
+		cmds = append(cmds, fmt.Sprintf(stopCommandTemplate, ctrlService, ctrlService, ctrlService))
+	} else {
+		cmds = append(cmds, fmt.Sprintf(stopCommandTemplate, workerService, workerService, ctrlService))
+	}
+	cmds = append(cmds, cleanup.PreResetCommands...)
+
+	resetCmd := append([]string{"k0s reset"}, cleanup.ResetFlags...)
+	hasKubeletRootDir := slices.ContainsFunc(cleanup.ResetFlags, func(flag string) bool {
+		return strings.HasPrefix(flag, "--kubelet-root-dir")
+	})
+	if !hasKubeletRootDir {
+		for _, cmd := range p.cloudInit.Commands {
+			if strings.Contains(cmd, "--kubelet-root-dir") {
+				finds := regex.FindStringSubmatch(cmd)
+				if len(finds) > 1 {
+					resetCmd = append(resetCmd, "--kubelet-root-dir "+finds[1])
+					break
+				}
+			}
+		}
+	}
+	cmds = append(cmds, strings.Join(resetCmd, " "))
+
+	if len(cleanup.ExtraPathsToRemove) > 0 {
+		cmds = append(cmds, "rm -rf -- "+shellescape.QuoteCommand(cleanup.ExtraPathsToRemove))
+	}
+	return cmds
+}
+
+// connect opens an SSH connection to the machine, through its bastion if any, pinned to the host keys verified
+// according to the host key policy.
+func (p *SSHProvisioner) connect(ctx context.Context) (*rig.Connection, error) {
+	authM, err := rig.ParseSSHPrivateKey(p.sshKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ssh key: %w", err)
+	}
+
+	dialer := &net.Dialer{Timeout: hostKeyScanTimeout}
+	dial := dialer.DialContext
+
+	var bastion *rig.SSH
+	if p.machine.Spec.Bastion != nil {
+		bastion, err = p.bastion(ctx, authM)
+		if err != nil {
+			setHostKeyVerifiedCondition(p.machine, err)
+			return nil, err
+		}
+
+		// The host key of the machine is verified through the bastion too.
+		bastionClient, err := dialBastion(ctx, bastion)
+		if err != nil {
+			return nil, err
+		}
+		defer bastionClient.Close()
+		dial = bastionClient.DialContext
+	}
+
+	hostKey, err := p.trustedHostKey(ctx, dial, p.machine.Spec.Address, p.machine.Spec.Port, &p.machine.Status.HostKey)
+	setHostKeyVerifiedCondition(p.machine, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify host key: %w", err)
+	}
+
+	connection := &rig.Connection{
+		SSH: &rig.SSH{
+			Address:     p.machine.Spec.Address,
+			Port:        p.machine.Spec.Port,
+			User:        p.machine.Spec.User,
+			HostKey:     hostKey,
+			AuthMethods: authM,
+			Bastion:     bastion,
+		},
+	}
+
+	if err := connection.Connect(); err != nil {
+		setHostKeyVerifiedCondition(p.machine, err)
+		return nil, fmt.Errorf("failed to connect to host: %w", err)
+	}
+
+	return connection, nil
+}
+
+// bastion returns the SSH configuration of the machine bastion, pinned to its verified host key. The bastion uses the
+// auth methods of the machine unless it has its own SSH key.
+func (p *SSHProvisioner) bastion(ctx context.Context, authM []ssh.AuthMethod) (*rig.SSH, error) {
+	spec := p.machine.Spec.Bastion
+
+	if len(p.bastionSSHKey) > 0 {
+		var err error
+		authM, err = rig.ParseSSHPrivateKey(p.bastionSSHKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bastion ssh key: %w", err)
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: hostKeyScanTimeout}
+	hostKey, err := p.trustedHostKey(ctx, dialer.DialContext, spec.Address, spec.Port, &p.machine.Status.BastionHostKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify bastion host key: %w", err)
+	}
+
+	return &rig.SSH{
+		Address:     spec.Address,
+		Port:        spec.Port,
+		User:        spec.User,
+		HostKey:     hostKey,
+		AuthMethods: authM,
+	}, nil
 }
 
 func (p *SSHProvisioner) uploadFile(fsys rigfs.Fsys, file provisioner.File) error {
@@ -0,0 +1,59 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+)
+
+func TestSSHCleanupCommands(t *testing.T) {
+	stopWorker := `(command -v systemctl > /dev/null 2>&1 && systemctl stop k0sworker) || ` +
+		`(command -v rc-service > /dev/null 2>&1 && rc-service k0sworker stop) || ` +
+		`(command -v service > /dev/null 2>&1 && service k0scontroller stop) || ` +
+		`(echo "Not a supported init system"; false)`
+
+	p := &SSHProvisioner{
+		machine: &infrastructure.RemoteMachine{},
+		cloudInit: &provisioner.InputProvisionData{
+			Commands: []string{"k0s install worker --kubelet-root-dir=/data/kubelet --token-file /etc/k0s.token"},
+		},
+	}
+	assert.Equal(t, []string{stopWorker, "k0s reset --kubelet-root-dir /data/kubelet"}, p.cleanupCommands(ModeWorker))
+
+	p.machine.Spec.Cleanup = &infrastructure.Cleanup{
+		PreResetCommands:   []string{"systemctl stop custom-agent"},
+		ResetFlags:         []string{"--data-dir=/data/k0s", "--kubelet-root-dir=/var/lib/kubelet"},
+		ExtraPathsToRemove: []string{"/data/containerd", "/etc/cni/net.d/10-calico.conflist", "/opt/my data"},
+	}
+	assert.Equal(t, []string{
+		"k0s etcd leave",
+		`(command -v systemctl > /dev/null 2>&1 && systemctl stop k0scontroller) || ` +
+			`(command -v rc-service > /dev/null 2>&1 && rc-service k0scontroller stop) || ` +
+			`(command -v service > /dev/null 2>&1 && service k0scontroller stop) || ` +
+			`(echo "Not a supported init system"; false)`,
+		"systemctl stop custom-agent",
+		"k0s reset --data-dir=/data/k0s --kubelet-root-dir=/var/lib/kubelet",
+		"rm -rf -- /data/containerd /etc/cni/net.d/10-calico.conflist '/opt/my data'",
+	}, p.cleanupCommands(ModeController))
+}
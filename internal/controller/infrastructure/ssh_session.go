@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/k0sproject/rig"
+	"github.com/k0sproject/rig/exec"
+	"github.com/k0sproject/rig/pkg/rigfs"
+	"golang.org/x/crypto/ssh"
+)
+
+// bootstrapCheckpointDir holds the markers of the completed bootstrap steps. It is next to the bootstrap sentinel file,
+// so the markers do not survive a reboot either.
+const bootstrapCheckpointDir = "/run/cluster-api/k0smotron-checkpoints"
+
+// commandRetryDelay is the time waited before retrying a failed bootstrap step.
+var commandRetryDelay = 5 * time.Second
+
+// sshSession is an SSH connection to the machine, with the options to run commands and upload files with.
+type sshSession struct {
+	connection *rig.Connection
+	fsys       rigfs.Fsys
+	execOpts   []exec.Option
+}
+
+// openSession opens an SSH session to the machine.
+func (p *SSHProvisioner) openSession(ctx context.Context) (*sshSession, error) {
+	connection, err := p.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If sudo is required, run commands with elevated permissions and use the
+	// sudo-capable filesystem for uploads.
+	s := &sshSession{
+		connection: connection,
+		fsys:       connection.Fsys(),
+	}
+	if p.machine.Spec.UseSudo {
+		s.execOpts = append(s.execOpts, exec.Sudo(connection))
+		s.fsys = connection.SudoFsys()
+	}
+	return s, nil
+}
+
+// reconnect replaces the connection of the session with a new one.
+func (p *SSHProvisioner) reconnect(ctx context.Context, s *sshSession) error {
+	s.connection.Disconnect()
+	newSession, err := p.openSession(ctx)
+	if err != nil {
+		return err
+	}
+	*s = *newSession
+	return nil
+}
+
+// runStep runs the given bootstrap step, retrying it on failure, and writes its checkpoint once it has succeeded.
+// While resume is true, the steps with a checkpoint have been completed by a previous attempt and are skipped. The
+// first step without a checkpoint ends the resumption.
+func (p *SSHProvisioner) runStep(ctx context.Context, s *sshSession, step int, checkpoint string, resume *bool, run func() (string, error)) error {
+	if *resume {
+		if _, err := s.fsys.Stat(checkpoint); err == nil {
+			p.log.Info("Skipping bootstrap step completed by a previous attempt", "step", p.machine.Status.BootstrapSteps[step].Name)
+			p.reporter.skip(step)
+			return nil
+		}
+		*resume = false
+	}
+
+	p.reporter.start(ctx, step)
+	var (
+		output string
+		err    error
+	)
+	for attempt := int32(0); ; attempt++ {
+		output, err = run()
+		if err == nil || attempt >= p.machine.Spec.CommandRetries || ctx.Err() != nil {
+			break
+		}
+
+		p.log.Error(err, "Bootstrap step failed, retrying", "attempt", attempt+1, "retries", p.machine.Spec.CommandRetries, "output", output)
+		select {
+		case <-ctx.Done():
+		case <-time.After(commandRetryDelay):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		// Unless the command exited, the connection may have been lost or closed by a timeout.
+		var exitErr *ssh.ExitError
+		if !errors.As(err, &exitErr) {
+			if rerr := p.reconnect(ctx, s); rerr != nil {
+				err = errors.Join(err, rerr)
+				break
+			}
+		}
+	}
+	p.reporter.finish(ctx, step, output, err)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCheckpoint(s.fsys, checkpoint); err != nil {
+		// The step is run again if the bootstrap is retried.
+		p.log.Error(err, "Failed to write bootstrap checkpoint", "path", checkpoint)
+	}
+	return nil
+}
+
+// exec runs the command on the machine. The command is aborted by closing the connection if it does not finish
+// within the command timeout, or if the context is cancelled.
+func (p *SSHProvisioner) exec(ctx context.Context, s *sshSession, cmd string) (string, error) {
+	if timeout := p.machine.Spec.CommandTimeout; timeout != nil && timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout.Duration)
+		defer cancel()
+	}
+
+	var (
+		output string
+		err    error
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		output, err = s.connection.ExecOutput(cmd, s.execOpts...)
+	}()
+
+	select {
+	case <-done:
+		return output, err
+	case <-ctx.Done():
+		// Closing the SSH client ends the session the command runs in.
+		s.connection.SSH.Disconnect()
+		<-done
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return output, fmt.Errorf("command timed out after %s", p.machine.Spec.CommandTimeout.Duration)
+		}
+		return output, ctx.Err()
+	}
+}
+
+// checkpointPath returns the path of the marker recording that the given bootstrap step has been completed. The
+// marker is derived from the machine UID and the content of the step, so the steps are neither skipped for another
+// RemoteMachine using the same host nor when the bootstrap data changes.
+func (p *SSHProvisioner) checkpointPath(step int, content string) string {
+	sum := sha256.Sum256([]byte(string(p.machine.UID) + "\n" + content))
+	return path.Join(bootstrapCheckpointDir, fmt.Sprintf("%03d-%x", step, sum[:8]))
+}
+
+func writeCheckpoint(fsys rigfs.Fsys, checkpoint string) error {
+	if err := fsys.MkDirAll(path.Dir(checkpoint), 0o700); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	f, err := fsys.OpenFile(checkpoint, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	return f.Close()
+}
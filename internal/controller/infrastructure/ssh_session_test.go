@@ -0,0 +1,118 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/k0sproject/rig/pkg/rigfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+)
+
+// checkpointFsys is a remote filesystem only recording the created files.
+type checkpointFsys struct {
+	rigfs.Fsys
+	files map[string]bool
+}
+
+type checkpointFile struct {
+	rigfs.File
+}
+
+func (f *checkpointFsys) Stat(path string) (fs.FileInfo, error) {
+	if !f.files[path] {
+		return nil, fs.ErrNotExist
+	}
+	return nil, nil
+}
+
+func (f *checkpointFsys) MkDirAll(string, fs.FileMode) error {
+	return nil
+}
+
+func (f *checkpointFsys) OpenFile(path string, _ int, _ fs.FileMode) (rigfs.File, error) {
+	f.files[path] = true
+	return checkpointFile{}, nil
+}
+
+func (checkpointFile) Close() error {
+	return nil
+}
+
+func TestRunStepResumesAfterCompletedSteps(t *testing.T) {
+	defer func(delay time.Duration) { commandRetryDelay = delay }(commandRetryDelay)
+	commandRetryDelay = 0
+	ctx := context.Background()
+
+	rm := &infrastructure.RemoteMachine{}
+	rm.UID = "machine-uid"
+	rm.Spec.CommandRetries = 1
+	p := &SSHProvisioner{
+		machine:  rm,
+		reporter: &bootstrapReporter{machine: rm, log: logr.Discard()},
+		log:      logr.Discard(),
+	}
+	session := &sshSession{fsys: &checkpointFsys{files: map[string]bool{}}}
+	commands := []string{"download k0s", "k0s install worker", "k0s start"}
+
+	// runAll runs the commands until one fails, and returns the number of runs of each command.
+	runAll := func(fail string) map[string]int {
+		p.reporter.init(ctx, commands)
+		runs := map[string]int{}
+		resume := true
+		for i, cmd := range commands {
+			err := p.runStep(ctx, session, i, p.checkpointPath(i, cmd), &resume, func() (string, error) {
+				runs[cmd]++
+				if cmd == fail {
+					return "failed", &ssh.ExitError{}
+				}
+				return "", nil
+			})
+			if err != nil {
+				break
+			}
+		}
+		return runs
+	}
+
+	runs := runAll("k0s install worker")
+	assert.Equal(t, map[string]int{"download k0s": 1, "k0s install worker": 2}, runs)
+	assert.Equal(t, infrastructure.BootstrapStepFailed, rm.Status.BootstrapSteps[1].Phase)
+	assert.Equal(t, infrastructure.BootstrapStepPending, rm.Status.BootstrapSteps[2].Phase)
+
+	runs = runAll("")
+	assert.Equal(t, map[string]int{"k0s install worker": 1, "k0s start": 1}, runs)
+	assert.Equal(t, "Completed by a previous attempt", rm.Status.BootstrapSteps[0].Message)
+	for _, step := range rm.Status.BootstrapSteps {
+		assert.Equal(t, infrastructure.BootstrapStepSucceeded, step.Phase)
+	}
+
+	// Another machine using the same host runs all the steps.
+	rm.UID = "other-machine-uid"
+	runs = runAll("")
+	require.Len(t, runs, 3)
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/masterzen/winrm"
+
+	api "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+)
+
+const (
+	// winrmBootstrapScriptPath is the path the bootstrap script is uploaded to.
+	winrmBootstrapScriptPath = `C:\bootstrap\k0smotron_bootstrap.ps1`
+	// winrmSentinelPath is the sentinel file written by the Windows bootstrap once it has succeeded.
+	winrmSentinelPath = `C:\run\cluster-api\bootstrap-success.complete`
+	// winrmUploadChunkSize is the size of the base64 chunks files are uploaded in. WinRM limits commands to 8191
+	// characters, and an encoded PowerShell command is about three times longer than its script.
+	winrmUploadChunkSize = 2048
+)
+
+// winrmWorkerStopScript stops the k0s worker service and removes the bootstrap task.
+const winrmWorkerStopScript = `$ErrorActionPreference = "Stop"
+Unregister-ScheduledTask -TaskName "k0s-bootstrap" -Confirm:$false -ErrorAction SilentlyContinue
+if (Get-Service -Name k0sworker -ErrorAction SilentlyContinue) { Stop-Service -Name k0sworker -Force }
+`
+
+// winrmWorkerResetScriptTemplate resets k0s, using the k0s binary the worker service was installed with, with the
+// given extra flags, and removes the bootstrap leftovers so the machine can be bootstrapped again.
+const winrmWorkerResetScriptTemplate = `$ErrorActionPreference = "Stop"
+$service = Get-CimInstance -ClassName Win32_Service -Filter "Name='k0sworker'"
+if ($service) {
+    if ($service.PathName -match '^"([^"]+)"') { $k0s = $Matches[1] } else { $k0s = ($service.PathName -split ' ')[0] }
+    & $k0s reset%s
+    if ($LASTEXITCODE -ne 0) { exit $LASTEXITCODE }
+}
+Remove-Item -Path "` + winrmSentinelPath + `" -Force -ErrorAction SilentlyContinue
+Remove-Item -Path "` + winrmBootstrapScriptPath + `" -Force -ErrorAction SilentlyContinue
+`
+
+// winrmRebootCommand reboots the machine once the command has returned.
+const winrmRebootCommand = `shutdown.exe /r /t 5`
+
+// WinRMProvisioner is responsible for provisioning a Windows remote machine using WinRM.
+type WinRMProvisioner struct {
+	bootstrapData []byte
+	format        provisioner.ProvisioningFormat
+	cloudInit     *provisioner.InputProvisionData
+	machine       *api.RemoteMachine
+	username      string
+	password      string
+	// caCert holds the CA certificate verifying the WinRM HTTPS certificate of the machine, if any.
+	caCert   []byte
+	reporter *bootstrapReporter
+	log      logr.Logger
+}
+
+// winrmExitError is returned when a command run over WinRM exits with a non-zero status.
+type winrmExitError struct {
+	status int
+}
+
+func (e *winrmExitError) Error() string {
+	return fmt.Sprintf("command exited with status %d", e.status)
+}
+
+// ExitStatus returns the exit status of the command.
+func (e *winrmExitError) ExitStatus() int {
+	return e.status
+}
+
+// Provision provisions a new Windows machine
+// The provisioning process is as follows:
+// 1. Render the bootstrap data as a PowerShell script
+// 2. Upload the script over WinRM and run it
+// 3. Check sentinel file at C:\run\cluster-api\bootstrap-success.complete
+// 4. success
+func (p *WinRMProvisioner) Provision(ctx context.Context) error {
+	script, err := p.bootstrapScript()
+	if err != nil {
+		return err
+	}
+
+	client, err := p.client()
+	if err != nil {
+		return err
+	}
+
+	runCmd := fmt.Sprintf(`powershell.exe -NoProfile -NonInteractive -ExecutionPolicy Bypass -File "%s"`, winrmBootstrapScriptPath)
+	p.reporter.init(ctx, []string{"Upload " + winrmBootstrapScriptPath, runCmd})
+
+	p.log.Info("Uploading bootstrap script", "path", winrmBootstrapScriptPath)
+	err = p.runStep(ctx, 0, func(ctx context.Context) (string, error) {
+		return p.uploadFile(ctx, client, winrmBootstrapScriptPath, script)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload bootstrap script: %w", err)
+	}
+
+	p.log.Info("running command", "command", runCmd)
+	err = p.runStep(ctx, 1, func(ctx context.Context) (string, error) {
+		return p.exec(ctx, client, runCmd)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run command: %w", err)
+	}
+
+	// Check for sentinel file
+	if _, err := p.execPS(ctx, client, fmt.Sprintf(`if (-not (Test-Path -Path %s)) { exit 1 }`, quotePS(winrmSentinelPath))); err != nil {
+		return errors.New("bootstrap sentinel file not found")
+	}
+
+	return nil
+}
+
+// Cleanup cleans up a Windows machine
+// The cleanup process is as follows:
+// 1. Run the custom cleanup commands, if any, and stop
+// 2. Stop the k0s worker service
+// 3. Run the pre-reset commands and k0s reset
+// 4. Remove the extra paths and reboot, if requested
+func (p *WinRMProvisioner) Cleanup(ctx context.Context, mode RemoteMachineMode) error {
+	client, err := p.client()
+	if err != nil {
+		return err
+	}
+
+	if p.machine.Spec.CleanUpCommands != nil {
+		p.log.Info("Cleaning up remote machine...")
+		var errs []error
+		for _, cmd := range p.machine.Spec.CleanUpCommands {
+			output, err := p.exec(ctx, client, cmd)
+			if err != nil {
+				p.log.Error(err, "failed to run command", "command", cmd, "output", output)
+				errs = append(errs, fmt.Errorf("command %q failed: %w", cmd, err))
+			} else {
+				p.log.Info("executed command", "command", cmd, "output", output)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+
+	switch mode {
+	case ModeNonK0s:
+		// If k0s is not the bootstrap provider, we have nothing to do.
+		p.log.Info("k0smotron is not the bootstrap provider and no cleanup commands specified, skipping cleanup")
+		return nil
+	case ModeController:
+		p.log.Info("k0s controllers are not supported on Windows, skipping cleanup")
+		return nil
+	}
+
+	cleanup := p.machine.Spec.Cleanup
+	if cleanup == nil {
+		cleanup = &api.Cleanup{}
+	}
+
+	p.log.Info("Cleaning up remote machine...")
+	// The cleanup goes on when a command fails, the errors are reported once done.
+	var errs []error
+	if output, err := p.execPS(ctx, client, winrmWorkerStopScript); err != nil {
+		p.log.Error(err, "failed to stop k0s worker", "output", output)
+		errs = append(errs, fmt.Errorf("failed to stop k0s worker: %w", err))
+	}
+	for _, cmd := range cleanup.PreResetCommands {
+		output, err := p.exec(ctx, client, cmd)
+		if err != nil {
+			p.log.Error(err, "failed to run command", "command", cmd, "output", output)
+			errs = append(errs, fmt.Errorf("command %q failed: %w", cmd, err))
+		} else {
+			p.log.Info("executed command", "command", cmd, "output", output)
+		}
+	}
+
+	var flags strings.Builder
+	for _, flag := range cleanup.ResetFlags {
+		flags.WriteString(" " + flag)
+	}
+	if output, err := p.execPS(ctx, client, fmt.Sprintf(winrmWorkerResetScriptTemplate, flags.String())); err != nil {
+		p.log.Error(err, "failed to reset k0s worker", "output", output)
+		errs = append(errs, fmt.Errorf("failed to reset k0s worker: %w", err))
+	} else {
+		p.log.Info("Reset k0s worker", "output", output)
+	}
+
+	if len(cleanup.ExtraPathsToRemove) > 0 {
+		removals := make([]string, 0, len(cleanup.ExtraPathsToRemove))
+		for _, path := range cleanup.ExtraPathsToRemove {
+			removals = append(removals, fmt.Sprintf(`Remove-Item -Path %s -Recurse -Force -ErrorAction SilentlyContinue`, quotePS(path)))
+		}
+		if output, err := p.execPS(ctx, client, strings.Join(removals, "\n")); err != nil {
+			p.log.Error(err, "failed to remove extra paths", "output", output)
+			errs = append(errs, fmt.Errorf("failed to remove extra paths: %w", err))
+		}
+	}
+
+	if len(errs) == 0 && cleanup.Reboot {
+		p.log.Info("Rebooting remote machine")
+		if output, err := p.exec(ctx, client, winrmRebootCommand); err != nil {
+			p.log.Error(err, "failed to reboot", "output", output)
+			errs = append(errs, fmt.Errorf("failed to reboot: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// bootstrapScript returns the PowerShell script bootstrapping the machine. Cloud-init bootstrap data is rendered
+// with the PowerShell provisioner, PowerShell bootstrap data is run as is.
+func (p *WinRMProvisioner) bootstrapScript() ([]byte, error) {
+	switch p.format {
+	case provisioner.PowershellProvisioningFormat:
+		return p.bootstrapData, nil
+	case provisioner.PowershellXMLProvisioningFormat:
+		script := bytes.TrimSpace(p.bootstrapData)
+		script = bytes.TrimPrefix(script, []byte("<powershell>"))
+		return bytes.TrimSuffix(script, []byte("</powershell>")), nil
+	case provisioner.IgnitionProvisioningFormat:
+		return nil, fmt.Errorf("bootstrap data format %q is not supported with the winrm transport", p.format)
+	default:
+		return (&provisioner.PowerShellProvisioner{}).ToProvisionData(p.cloudInit)
+	}
+}
+
+// client returns a WinRM client for the machine. The client connects on each command.
+func (p *WinRMProvisioner) client() (*winrm.Client, error) {
+	spec := p.machine.Spec.WinRM
+	if spec == nil {
+		return nil, errors.New("winrm connection is not defined")
+	}
+
+	endpoint := winrm.NewEndpoint(p.machine.Spec.Address, spec.GetPort(), spec.UseHTTPS, spec.Insecure, p.caCert, nil, nil, 0)
+	params := *winrm.DefaultParameters
+	if spec.UseNTLM {
+		params.TransportDecorator = func() winrm.Transporter { return &winrm.ClientNTLM{} }
+	}
+
+	client, err := winrm.NewClientWithParameters(endpoint, p.username, p.password, &params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create winrm client: %w", err)
+	}
+	return client, nil
+}
+
+// runStep runs the given bootstrap step, retrying it on failure. Each attempt is aborted if it does not finish within
+// the command timeout.
+func (p *WinRMProvisioner) runStep(ctx context.Context, step int, run func(ctx context.Context) (string, error)) error {
+	p.reporter.start(ctx, step)
+	var (
+		output string
+		err    error
+	)
+	for attempt := int32(0); ; attempt++ {
+		output, err = p.runWithTimeout(ctx, run)
+		if err == nil || attempt >= p.machine.Spec.CommandRetries || ctx.Err() != nil {
+			break
+		}
+
+		p.log.Error(err, "Bootstrap step failed, retrying", "attempt", attempt+1, "retries", p.machine.Spec.CommandRetries, "output", output)
+		select {
+		case <-ctx.Done():
+		case <-time.After(commandRetryDelay):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	p.reporter.finish(ctx, step, output, err)
+	return err
+}
+
+func (p *WinRMProvisioner) runWithTimeout(ctx context.Context, run func(ctx context.Context) (string, error)) (string, error) {
+	timeout := p.machine.Spec.CommandTimeout
+	if timeout == nil || timeout.Duration <= 0 {
+		return run(ctx)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout.Duration)
+	defer cancel()
+	output, err := run(runCtx)
+	if ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("command timed out after %s", timeout.Duration)
+	}
+	return output, err
+}
+
+// uploadFile writes the content to the given path on the machine. The content is appended to a temporary file in
+// base64 chunks, which is decoded once complete.
+func (p *WinRMProvisioner) uploadFile(ctx context.Context, client *winrm.Client, path string, content []byte) (string, error) {
+	tmpPath := quotePS(path + ".b64")
+	cmds := []string{fmt.Sprintf(`New-Item -ItemType Directory -Force -Path (Split-Path -Parent %s) | Out-Null; Set-Content -Path %s -Value "" -NoNewline`, quotePS(path), tmpPath)}
+	for chunk := range slices.Chunk([]byte(base64.StdEncoding.EncodeToString(content)), winrmUploadChunkSize) {
+		cmds = append(cmds, fmt.Sprintf(`Add-Content -Path %s -Value "%s" -NoNewline`, tmpPath, chunk))
+	}
+	cmds = append(cmds, fmt.Sprintf(`[IO.File]::WriteAllBytes(%s, [Convert]::FromBase64String((Get-Content -Path %s -Raw))); Remove-Item -Path %s`, quotePS(path), tmpPath, tmpPath))
+
+	for _, cmd := range cmds {
+		if output, err := p.execPS(ctx, client, cmd); err != nil {
+			return output, err
+		}
+	}
+	return "", nil
+}
+
+// execPS runs the PowerShell script on the machine.
+func (p *WinRMProvisioner) execPS(ctx context.Context, client *winrm.Client, script string) (string, error) {
+	cmd := winrm.Powershell(script)
+	if cmd == "" {
+		return "", errors.New("failed to encode powershell command")
+	}
+	return p.exec(ctx, client, cmd)
+}
+
+// exec runs the command in a cmd.exe shell on the machine, and returns its combined stdout and stderr. The command
+// is aborted if the context is cancelled.
+func (p *WinRMProvisioner) exec(ctx context.Context, client *winrm.Client, cmd string) (string, error) {
+	stdout, stderr, exitCode, err := client.RunCmdWithContext(ctx, cmd)
+	output := stdout + stderr
+	if err != nil {
+		return output, err
+	}
+	if exitCode != 0 {
+		return output, &winrmExitError{status: exitCode}
+	}
+	return output, nil
+}
+
+// quotePS quotes the string as a single-quoted PowerShell string.
+func quotePS(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
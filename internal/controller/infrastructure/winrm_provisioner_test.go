@@ -0,0 +1,235 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infrastructure
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	infrastructure "github.com/k0sproject/k0smotron/v2/api/infrastructure/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/provisioner"
+)
+
+const winrmEnvelope = `<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:a="http://schemas.xmlsoap.org/ws/2004/08/addressing" xmlns:rsp="http://schemas.microsoft.com/wbem/wsman/1/windows/shell"><s:Header><a:Action>%s</a:Action></s:Header><s:Body>%s</s:Body></s:Envelope>`
+
+var winrmCommandRegex = regexp.MustCompile(`(?s)<rsp:Command><!\[CDATA\[(.*?)\]\]></rsp:Command>`)
+
+// winrmServer is a mocked WinRM endpoint recording the commands run, with the PowerShell commands decoded.
+type winrmServer struct {
+	mu       sync.Mutex
+	commands []string
+	// result returns the output and the exit code of a command.
+	result func(cmd string) (string, int)
+	last   string
+}
+
+func (s *winrmServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	request := string(body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var response string
+	switch {
+	case strings.Contains(request, "http://schemas.xmlsoap.org/ws/2004/09/transfer/Create<"):
+		response = fmt.Sprintf(winrmEnvelope, "http://schemas.xmlsoap.org/ws/2004/09/transfer/CreateResponse",
+			"<rsp:Shell><rsp:ShellId>shell</rsp:ShellId></rsp:Shell>")
+	case strings.Contains(request, "http://schemas.microsoft.com/wbem/wsman/1/windows/shell/Command<"):
+		s.last = decodeWinRMCommand(winrmCommandRegex.FindStringSubmatch(request)[1])
+		s.commands = append(s.commands, s.last)
+		response = fmt.Sprintf(winrmEnvelope, "http://schemas.microsoft.com/wbem/wsman/1/windows/shell/CommandResponse",
+			"<rsp:CommandResponse><rsp:CommandId>command</rsp:CommandId></rsp:CommandResponse>")
+	case strings.Contains(request, "http://schemas.microsoft.com/wbem/wsman/1/windows/shell/Receive<"):
+		output, exitCode := "", 0
+		if s.result != nil {
+			output, exitCode = s.result(s.last)
+		}
+		response = fmt.Sprintf(winrmEnvelope, "http://schemas.microsoft.com/wbem/wsman/1/windows/shell/ReceiveResponse",
+			`<rsp:ReceiveResponse><rsp:Stream Name="stdout" CommandId="command">`+base64.StdEncoding.EncodeToString([]byte(output))+`</rsp:Stream>`+
+				`<rsp:CommandState CommandId="command" State="http://schemas.microsoft.com/wbem/wsman/1/windows/shell/CommandState/Done"><rsp:ExitCode>`+strconv.Itoa(exitCode)+`</rsp:ExitCode></rsp:CommandState></rsp:ReceiveResponse>`)
+	default:
+		// Signal and Delete responses are not parsed.
+		response = fmt.Sprintf(winrmEnvelope, "http://schemas.microsoft.com/wbem/wsman/1/windows/shell/SignalResponse", "")
+	}
+
+	w.Header().Set("Content-Type", "application/soap+xml;charset=UTF-8")
+	_, _ = w.Write([]byte(response))
+}
+
+// decodeWinRMCommand decodes the script of an encoded PowerShell command.
+func decodeWinRMCommand(cmd string) string {
+	encoded, ok := strings.CutPrefix(cmd, "powershell.exe -EncodedCommand ")
+	if !ok {
+		return cmd
+	}
+	data, _ := base64.StdEncoding.DecodeString(encoded)
+	chars := make([]uint16, len(data)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	return strings.TrimPrefix(string(utf16.Decode(chars)), "$ProgressPreference = 'SilentlyContinue';")
+}
+
+func newWinRMProvisioner(t *testing.T, server *winrmServer) *WinRMProvisioner {
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(ts.URL, "http://"))
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	rm := &infrastructure.RemoteMachine{}
+	rm.Spec.Address = host
+	rm.Spec.Transport = infrastructure.TransportWinRM
+	rm.Spec.WinRM = &infrastructure.WinRM{Port: portNumber}
+	return &WinRMProvisioner{
+		machine:  rm,
+		username: "Administrator",
+		password: "secret",
+		reporter: &bootstrapReporter{machine: rm, log: logr.Discard()},
+		log:      logr.Discard(),
+	}
+}
+
+func TestWinRMProvision(t *testing.T) {
+	server := &winrmServer{}
+	p := newWinRMProvisioner(t, server)
+	p.cloudInit = &provisioner.InputProvisionData{
+		Files:    []provisioner.File{{Path: `C:\bootstrap\k0s_install.ps1`, Content: strings.Repeat("Write-Host 'k0s'\n", 300)}},
+		Commands: []string{`powershell.exe -NoProfile -NonInteractive -File "C:\bootstrap\k0s_install.ps1"`},
+	}
+
+	require.NoError(t, p.Provision(context.Background()))
+
+	// The bootstrap script is uploaded in chunks, then decoded.
+	var uploaded strings.Builder
+	chunk := regexp.MustCompile(`^Add-Content -Path '.*\.b64' -Value "(.*)" -NoNewline$`)
+	for _, cmd := range server.commands {
+		if m := chunk.FindStringSubmatch(cmd); m != nil {
+			uploaded.WriteString(m[1])
+		}
+	}
+	script, err := base64.StdEncoding.DecodeString(uploaded.String())
+	require.NoError(t, err)
+	expected, err := (&provisioner.PowerShellProvisioner{}).ToProvisionData(p.cloudInit)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(script))
+
+	runCmd := `powershell.exe -NoProfile -NonInteractive -ExecutionPolicy Bypass -File "C:\bootstrap\k0smotron_bootstrap.ps1"`
+	require.GreaterOrEqual(t, len(server.commands), 2)
+	assert.Equal(t, runCmd, server.commands[len(server.commands)-2])
+	assert.Contains(t, server.commands[len(server.commands)-1], `Test-Path -Path 'C:\run\cluster-api\bootstrap-success.complete'`)
+
+	require.Len(t, p.machine.Status.BootstrapSteps, 2)
+	assert.Equal(t, `Upload C:\bootstrap\k0smotron_bootstrap.ps1`, p.machine.Status.BootstrapSteps[0].Name)
+	for _, step := range p.machine.Status.BootstrapSteps {
+		assert.Equal(t, infrastructure.BootstrapStepSucceeded, step.Phase)
+	}
+}
+
+func TestWinRMProvisionFailedCommand(t *testing.T) {
+	server := &winrmServer{
+		result: func(cmd string) (string, int) {
+			if strings.Contains(cmd, "-File") {
+				return "DISM failed with exit code 87", 1
+			}
+			return "", 0
+		},
+	}
+	p := newWinRMProvisioner(t, server)
+	p.format = provisioner.PowershellXMLProvisioningFormat
+	p.bootstrapData = []byte("<powershell>\nWrite-Host 'k0s'\n</powershell>\n")
+
+	require.Error(t, p.Provision(context.Background()))
+
+	failed := failedBootstrapStep(p.machine)
+	require.NotNil(t, failed)
+	assert.Equal(t, int32(1), *failed.ExitCode)
+	assert.Equal(t, "DISM failed with exit code 87", failed.Output)
+}
+
+func TestWinRMCleanup(t *testing.T) {
+	server := &winrmServer{}
+	p := newWinRMProvisioner(t, server)
+
+	require.NoError(t, p.Cleanup(context.Background(), ModeController))
+	assert.Empty(t, server.commands)
+
+	require.NoError(t, p.Cleanup(context.Background(), ModeWorker))
+	assert.Equal(t, []string{winrmWorkerStopScript, fmt.Sprintf(winrmWorkerResetScriptTemplate, "")}, server.commands)
+
+	server.commands = nil
+	p.machine.Spec.Cleanup = &infrastructure.Cleanup{
+		PreResetCommands:   []string{"sc.exe delete calico"},
+		ResetFlags:         []string{`--data-dir=D:\k0s`},
+		ExtraPathsToRemove: []string{`C:\etc\cni`},
+		Reboot:             true,
+	}
+	require.NoError(t, p.Cleanup(context.Background(), ModeWorker))
+	require.Len(t, server.commands, 5)
+	assert.Equal(t, "sc.exe delete calico", server.commands[1])
+	assert.Contains(t, server.commands[2], `& $k0s reset --data-dir=D:\k0s`)
+	assert.Equal(t, `Remove-Item -Path 'C:\etc\cni' -Recurse -Force -ErrorAction SilentlyContinue`, server.commands[3])
+	assert.Equal(t, winrmRebootCommand, server.commands[4])
+
+	server.commands = nil
+	p.machine.Spec.CleanUpCommands = []string{`C:\k0s\k0s.exe reset`}
+	require.NoError(t, p.Cleanup(context.Background(), ModeWorker))
+	assert.Equal(t, []string{`C:\k0s\k0s.exe reset`}, server.commands)
+}
+
+func TestWinRMCleanupFailedCommand(t *testing.T) {
+	server := &winrmServer{
+		result: func(cmd string) (string, int) {
+			if cmd == "sc.exe delete calico" {
+				return "service not found", 1
+			}
+			return "", 0
+		},
+	}
+	p := newWinRMProvisioner(t, server)
+	p.machine.Spec.Cleanup = &infrastructure.Cleanup{
+		PreResetCommands:   []string{"sc.exe delete calico"},
+		ExtraPathsToRemove: []string{`C:\etc\cni`},
+		Reboot:             true,
+	}
+
+	// The reset and the removals still run, only the reboot is skipped.
+	require.ErrorContains(t, p.Cleanup(context.Background(), ModeWorker), "sc.exe delete calico")
+	require.Len(t, server.commands, 4)
+	assert.Contains(t, server.commands[2], "& $k0s reset")
+	assert.Equal(t, `Remove-Item -Path 'C:\etc\cni' -Recurse -Force -ErrorAction SilentlyContinue`, server.commands[3])
+}
@@ -0,0 +1,475 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/controller/util"
+)
+
+// etcdRestoreFinalizer is the finalizer used by EtcdRestore to release the Cluster and clean up the restore jobs.
+const etcdRestoreFinalizer = "etcdrestores.k0smotron.io/finalizer"
+
+// EtcdRestoreReconciler reconciles an EtcdRestore object
+type EtcdRestoreReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=k0smotron.io,resources=etcdrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k0smotron.io,resources=etcdrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=k0smotron.io,resources=etcdrestores/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;patch
+
+// Reconcile restores the etcd members of a k0smotron Cluster from a snapshot. While the restore runs the Cluster
+// is annotated with km.EtcdRestoreInProgressAnnotation so the Cluster controller leaves the StatefulSets alone.
+func (r *EtcdRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	restore := &km.EtcdRestore{}
+	if err := r.Get(ctx, req.NamespacedName, restore); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if finalizerAdded, err := util.EnsureFinalizer(ctx, r.Client, restore, etcdRestoreFinalizer); err != nil || finalizerAdded {
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(restore, r.Client)
+	if err != nil {
+		logger.Error(err, "Failed to configure the patch helper")
+		return ctrl.Result{Requeue: true}, nil
+	}
+	defer func() {
+		if perr := patchHelper.Patch(ctx, restore); perr != nil {
+			logger.Error(perr, "Unable to update EtcdRestore")
+		}
+	}()
+
+	kmc := &km.Cluster{}
+	err = r.Get(ctx, types.NamespacedName{Name: restore.Spec.ClusterName, Namespace: restore.Namespace}, kmc)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	clusterFound := err == nil
+
+	if !restore.DeletionTimestamp.IsZero() {
+		if clusterFound {
+			if err := r.reconcileDelete(ctx, restore, kmc); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		controllerutil.RemoveFinalizer(restore, etcdRestoreFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	if restore.Status.Phase == km.EtcdRestorePhaseCompleted || restore.Status.Phase == km.EtcdRestorePhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	if !clusterFound {
+		setEtcdRestoreCondition(restore, metav1.ConditionFalse, km.EtcdRestoreInProgressReason, fmt.Sprintf("Waiting for Cluster %s", restore.Spec.ClusterName))
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	if restore.Status.Phase == km.EtcdRestorePhasePending {
+		if kmc.Spec.Storage.Type != km.StorageTypeEtcd {
+			failEtcdRestore(restore, fmt.Sprintf("Cluster %s does not use the etcd storage type", kmc.Name))
+			return ctrl.Result{}, nil
+		}
+		if err := restore.Spec.Source.Validate(); err != nil {
+			failEtcdRestore(restore, fmt.Sprintf("Invalid source: %v", err))
+			return ctrl.Result{}, nil
+		}
+	}
+
+	hostClient, err := r.getHostClient(ctx, kmc)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting hosting cluster client: %w", err)
+	}
+
+	switch restore.Status.Phase {
+	case km.EtcdRestorePhasePending:
+		return r.lockCluster(ctx, hostClient, restore, kmc)
+	case km.EtcdRestorePhaseScalingDown:
+		return r.scaleDown(ctx, hostClient, restore, kmc)
+	case km.EtcdRestorePhaseRestoring:
+		return r.restoreMembers(ctx, hostClient, restore, kmc)
+	case km.EtcdRestorePhaseScalingUp:
+		return r.scaleUp(ctx, hostClient, restore, kmc)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// lockCluster marks the Cluster as being restored and records the replica counts to bring back afterwards.
+func (r *EtcdRestoreReconciler) lockCluster(ctx context.Context, hostClient client.Client, restore *km.EtcdRestore, kmc *km.Cluster) (ctrl.Result, error) {
+	if owner, ok := kmc.Annotations[km.EtcdRestoreInProgressAnnotation]; ok && owner != restore.Name {
+		setEtcdRestoreCondition(restore, metav1.ConditionFalse, km.EtcdRestoreInProgressReason, fmt.Sprintf("Waiting for EtcdRestore %s to finish", owner))
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	etcdSts := &apps.StatefulSet{}
+	if err := hostClient.Get(ctx, types.NamespacedName{Name: kmc.GetEtcdStatefulSetName(), Namespace: kmc.Namespace}, etcdSts); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting etcd statefulset: %w", err)
+	}
+
+	if err := controllerutil.SetOwnerReference(kmc, restore, r.Scheme); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.setClusterAnnotation(ctx, kmc, restore.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	restore.Status.EtcdReplicas = *etcdSts.Spec.Replicas
+	restore.Status.Replicas = kmc.Spec.Replicas
+	restore.Status.StartTime = new(metav1.Now())
+	restore.Status.Phase = km.EtcdRestorePhaseScalingDown
+	setEtcdRestoreCondition(restore, metav1.ConditionFalse, km.EtcdRestoreInProgressReason, "Scaling down the control plane")
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *EtcdRestoreReconciler) scaleDown(ctx context.Context, hostClient client.Client, restore *km.EtcdRestore, kmc *km.Cluster) (ctrl.Result, error) {
+	// k0s goes first so the apiservers do not crash-loop against a disappearing etcd.
+	k0sDone, err := scaleStatefulSet(ctx, hostClient, kmc.Namespace, kmc.GetStatefulSetName(), 0)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !k0sDone {
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	etcdDone, err := scaleStatefulSet(ctx, hostClient, kmc.Namespace, kmc.GetEtcdStatefulSetName(), 0)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !etcdDone {
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	restore.Status.Phase = km.EtcdRestorePhaseRestoring
+	setEtcdRestoreCondition(restore, metav1.ConditionFalse, km.EtcdRestoreInProgressReason, "Restoring etcd members from the snapshot")
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *EtcdRestoreReconciler) restoreMembers(ctx context.Context, hostClient client.Client, restore *km.EtcdRestore, kmc *km.Cluster) (ctrl.Result, error) {
+	// All the restore jobs mount the snapshot PVC. It is usually ReadWriteOnce and can't be attached to several nodes at
+	// once, so the members are restored one after another.
+	sequential := restore.Spec.Source.PVC != nil
+	succeeded := int32(0)
+	for member := range restore.Status.EtcdReplicas {
+		job := &batchv1.Job{}
+		err := hostClient.Get(ctx, types.NamespacedName{Name: kmc.GetEtcdRestoreJobName(member), Namespace: kmc.Namespace}, job)
+		if apierrors.IsNotFound(err) {
+			desired := generateEtcdRestoreJob(kmc, restore, member)
+			if err := hostClient.Create(ctx, &desired); err != nil {
+				return ctrl.Result{}, fmt.Errorf("error creating etcd restore job: %w", err)
+			}
+			if sequential {
+				break
+			}
+			continue
+		}
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error getting etcd restore job: %w", err)
+		}
+
+		complete := false
+		for _, c := range job.Status.Conditions {
+			if c.Status != v1.ConditionTrue {
+				continue
+			}
+			switch c.Type {
+			case batchv1.JobComplete:
+				complete = true
+			case batchv1.JobFailed:
+				failEtcdRestore(restore, fmt.Sprintf("Restore job %s failed: %s", job.Name, c.Message))
+				return ctrl.Result{}, nil
+			}
+		}
+		if complete {
+			succeeded++
+		} else if sequential {
+			break
+		}
+	}
+
+	if succeeded < restore.Status.EtcdReplicas {
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	restore.Status.Phase = km.EtcdRestorePhaseScalingUp
+	setEtcdRestoreCondition(restore, metav1.ConditionFalse, km.EtcdRestoreInProgressReason, "Scaling up the control plane")
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *EtcdRestoreReconciler) scaleUp(ctx context.Context, hostClient client.Client, restore *km.EtcdRestore, kmc *km.Cluster) (ctrl.Result, error) {
+	// All members are started at once: a restored member cannot become ready before it has quorum, so the
+	// one-member-at-a-time scale up done by the Cluster controller would never progress.
+	etcdReady, err := scaleStatefulSet(ctx, hostClient, kmc.Namespace, kmc.GetEtcdStatefulSetName(), restore.Status.EtcdReplicas)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !etcdReady {
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	k0sReady, err := scaleStatefulSet(ctx, hostClient, kmc.Namespace, kmc.GetStatefulSetName(), restore.Status.Replicas)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !k0sReady {
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if err := r.reconcileDelete(ctx, restore, kmc); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	restore.Status.CompletionTime = new(metav1.Now())
+	restore.Status.Phase = km.EtcdRestorePhaseCompleted
+	setEtcdRestoreCondition(restore, metav1.ConditionTrue, km.EtcdRestoreSucceededReason, "")
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDelete removes the restore jobs and hands the Cluster back to the Cluster controller.
+func (r *EtcdRestoreReconciler) reconcileDelete(ctx context.Context, restore *km.EtcdRestore, kmc *km.Cluster) error {
+	if kmc.Annotations[km.EtcdRestoreInProgressAnnotation] != restore.Name {
+		return nil
+	}
+
+	hostClient, err := r.getHostClient(ctx, kmc)
+	if err != nil {
+		return fmt.Errorf("error getting hosting cluster client: %w", err)
+	}
+	for member := range restore.Status.EtcdReplicas {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: kmc.GetEtcdRestoreJobName(member), Namespace: kmc.Namespace}}
+		err := hostClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting etcd restore job: %w", err)
+		}
+	}
+
+	return r.setClusterAnnotation(ctx, kmc, "")
+}
+
+// setClusterAnnotation sets the restore lock annotation on the Cluster, or removes it if restoreName is empty.
+func (r *EtcdRestoreReconciler) setClusterAnnotation(ctx context.Context, kmc *km.Cluster, restoreName string) error {
+	original := kmc.DeepCopy()
+	if restoreName == "" {
+		delete(kmc.Annotations, km.EtcdRestoreInProgressAnnotation)
+	} else {
+		if kmc.Annotations == nil {
+			kmc.Annotations = map[string]string{}
+		}
+		kmc.Annotations[km.EtcdRestoreInProgressAnnotation] = restoreName
+	}
+
+	if err := r.Patch(ctx, kmc, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("error updating cluster annotations: %w", err)
+	}
+	return nil
+}
+
+func (r *EtcdRestoreReconciler) getHostClient(ctx context.Context, kmc *km.Cluster) (client.Client, error) {
+	if kmc.Spec.RemoteHostCluster == nil || kmc.Spec.RemoteHostCluster.KubeconfigRef == nil {
+		return r.Client, nil
+	}
+	c, _, _, err := util.GetKmcClientFromClusterKubeconfigSecret(ctx, r.Client, kmc.Spec.RemoteHostCluster.KubeconfigRef)
+	return c, err
+}
+
+// scaleStatefulSet sets the replicas of the StatefulSet and reports whether it has settled at that size
+// with all replicas ready.
+func scaleStatefulSet(ctx context.Context, c client.Client, namespace, name string, replicas int32) (bool, error) {
+	sts := &apps.StatefulSet{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, sts); err != nil {
+		return false, fmt.Errorf("error getting statefulset %s: %w", name, err)
+	}
+
+	if sts.Spec.Replicas == nil || *sts.Spec.Replicas != replicas {
+		patched := sts.DeepCopy()
+		patched.Spec.Replicas = &replicas
+		if err := c.Patch(ctx, patched, client.MergeFrom(sts)); err != nil {
+			return false, fmt.Errorf("error scaling statefulset %s: %w", name, err)
+		}
+		return false, nil
+	}
+
+	return sts.Status.ObservedGeneration >= sts.Generation &&
+		sts.Status.Replicas == replicas &&
+		sts.Status.ReadyReplicas == replicas, nil
+}
+
+func generateEtcdRestoreJob(kmc *km.Cluster, restore *km.EtcdRestore, member int32) batchv1.Job {
+	selectorLabels := util.LabelsForEtcdK0smotronCluster(kmc)
+	labels := make(map[string]string, len(selectorLabels)+2)
+	maps.Copy(labels, selectorLabels)
+	labels[util.ComponentLabel] = util.ComponentEtcd
+	labels["k0smotron.io/etcd-restore"] = restore.Name
+	// The restore pods must not be selected by the etcd service or StatefulSet.
+	labels["component"] = "etcd-restore"
+
+	memberName := fmt.Sprintf("%s-%d", kmc.GetEtcdStatefulSetName(), member)
+	restoreContainer := v1.Container{
+		Name:            "etcd-restore",
+		Image:           kmc.Spec.Storage.Etcd.Image,
+		ImagePullPolicy: v1.PullIfNotPresent,
+		Command:         []string{"/bin/bash"},
+		Args:            []string{"-c", etcdRestoreScript},
+		Env: []v1.EnvVar{
+			{Name: "SNAPSHOT", Value: restore.Spec.Snapshot},
+			{Name: "MEMBER_NAME", Value: memberName},
+			{Name: "SVC_NAME", Value: kmc.GetEtcdServiceName()},
+			{Name: "ETCD_INITIAL_CLUSTER", Value: initialCluster(kmc, restore.Status.EtcdReplicas)},
+		},
+		VolumeMounts: []v1.VolumeMount{
+			{Name: "etcd-data", MountPath: "/var/lib/k0s/etcd"},
+			{Name: "snapshots", MountPath: "/snapshots", ReadOnly: restore.Spec.Source.PVC != nil},
+		},
+	}
+
+	podSpec := v1.PodSpec{
+		RestartPolicy:                v1.RestartPolicyOnFailure,
+		AutomountServiceAccountToken: new(false),
+		SecurityContext: &v1.PodSecurityContext{
+			FSGroup: new(int64(1001)),
+		},
+		Containers: []v1.Container{restoreContainer},
+		Volumes: []v1.Volume{{
+			Name: "etcd-data",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: fmt.Sprintf("etcd-data-%s", memberName)},
+			},
+		}},
+	}
+
+	if restore.Spec.Source.PVC != nil {
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name: "snapshots",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: restore.Spec.Source.PVC.ClaimName, ReadOnly: true},
+			},
+		})
+	} else if restore.Spec.Source.S3 != nil {
+		download := s3Container(restore.Spec.Source.S3, "etcd-snapshot-download", etcdSnapshotDownloadScript)
+		download.Env = append(download.Env, v1.EnvVar{Name: "SNAPSHOT", Value: restore.Spec.Snapshot})
+		podSpec.InitContainers = []v1.Container{download}
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name:         "snapshots",
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		})
+	}
+
+	if kmc.Spec.ServiceAccount != "" {
+		podSpec.ServiceAccountName = kmc.Spec.ServiceAccount
+	}
+
+	return batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kmc.GetEtcdRestoreJobName(member),
+			Namespace: kmc.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: new(int32(3)),
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func setEtcdRestoreCondition(restore *km.EtcdRestore, status metav1.ConditionStatus, reason, message string) {
+	conditions.Set(restore, metav1.Condition{
+		Type:    km.EtcdRestoreCompletedCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func failEtcdRestore(restore *km.EtcdRestore, message string) {
+	restore.Status.Phase = km.EtcdRestorePhaseFailed
+	restore.Status.CompletionTime = new(metav1.Now())
+	setEtcdRestoreCondition(restore, metav1.ConditionFalse, km.EtcdRestoreFailedReason, message)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EtcdRestoreReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&km.EtcdRestore{}).
+		Complete(r)
+}
+
+const etcdSnapshotDownloadScript = `
+set -eu
+
+aws s3 cp "${S3_URL}/${SNAPSHOT}" "/snapshots/${SNAPSHOT}"
+`
+
+// The restored data replaces the member data directory. The "existing" marker is dropped with the rest of the old
+// data, and since the member now has a snapshot db the init container does not try to re-add it to the cluster.
+const etcdRestoreScript = `
+set -eu
+
+rm -rf /var/lib/k0s/etcd/.restore
+etcdutl snapshot restore "/snapshots/${SNAPSHOT}" \
+  --name "${MEMBER_NAME}" \
+  --initial-cluster "${ETCD_INITIAL_CLUSTER}" \
+  --initial-advertise-peer-urls "https://${MEMBER_NAME}.${SVC_NAME}:2380" \
+  --data-dir /var/lib/k0s/etcd/.restore
+
+find /var/lib/k0s/etcd -mindepth 1 -maxdepth 1 ! -name .restore -exec rm -rf {} +
+mv /var/lib/k0s/etcd/.restore/* /var/lib/k0s/etcd/
+rmdir /var/lib/k0s/etcd/.restore
+echo "Restored ${MEMBER_NAME} from ${SNAPSHOT}"
+`
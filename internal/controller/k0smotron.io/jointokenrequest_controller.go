@@ -25,6 +25,7 @@ import (
 	"io"
 	"maps"
 	"strings"
+	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	k0smotroniov1beta2 "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
@@ -48,8 +51,13 @@ import (
 	"github.com/k0sproject/k0smotron/v2/internal/exec"
 )
 
-// joinTokenRequestFinalizer is the finalizer used by JoinTokenRequest to clean up resources.
-const joinTokenRequestFinalizer = "jointokenrequests.k0smotron.io/finalizer"
+const (
+	// joinTokenRequestFinalizer is the finalizer used by JoinTokenRequest to clean up resources.
+	joinTokenRequestFinalizer = "jointokenrequests.k0smotron.io/finalizer"
+	// joinTokenRotationGracePeriod is the time a renewed token stays valid, so the consumers of the Secret can pick
+	// up the new token. It is capped by spec.renewBefore, so the previous token is invalidated before it expires.
+	joinTokenRotationGracePeriod = 10 * time.Minute
+)
 
 // JoinTokenRequestReconciler reconciles a JoinTokenRequest object
 type JoinTokenRequestReconciler struct {
@@ -57,12 +65,19 @@ type JoinTokenRequestReconciler struct {
 	Scheme     *runtime.Scheme
 	ClientSet  *kubernetes.Clientset
 	RESTConfig *rest.Config
+	Recorder   record.EventRecorder
+
+	controller    controller.Controller
+	joinWatchesMu sync.Mutex
+	// joinWatches are the watches of the hosted clusters the joins of tokens are tracked in, by cluster.
+	joinWatches map[client.ObjectKey]*joinWatch
 }
 
 //+kubebuilder:rbac:groups=k0smotron.io,resources=jointokenrequests,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=k0smotron.io,resources=jointokenrequests/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=k0smotron.io,resources=jointokenrequests/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile reconciles a JoinTokenRequest object by creating a join token for the referenced
 // cluster and storing it in a secret.
@@ -141,12 +156,18 @@ func (r *JoinTokenRequestReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	if isJTRBeingDeleted {
+		r.unwatchJoins(req.NamespacedName)
 		if controllerutil.ContainsFinalizer(&jtr, joinTokenRequestFinalizer) {
 			if pod != nil {
 				logger.Info("Invalidating token before deletion")
-				if err := r.invalidateToken(ctx, &jtr, pod); err != nil {
+				if err := r.invalidateToken(ctx, jtr.Status.TokenID, pod); err != nil {
 					return ctrl.Result{}, err
 				}
+				if jtr.Status.PreviousTokenID != "" {
+					if err := r.invalidateToken(ctx, jtr.Status.PreviousTokenID, pod); err != nil {
+						return ctrl.Result{}, err
+					}
+				}
 			}
 			controllerutil.RemoveFinalizer(&jtr, joinTokenRequestFinalizer)
 			if err := r.Update(ctx, &jtr); err != nil {
@@ -156,11 +177,77 @@ func (r *JoinTokenRequestReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 
-	if jtr.Status.TokenID != "" {
-		logger.Info("Already reconciled")
+	expiry, err := parseExpiry(jtr.Spec.Expiry)
+	if err != nil {
+		reconcileFailureMessage = "Invalid expiry"
+		return ctrl.Result{}, err
+	}
+	if jtr.Spec.RenewBefore != nil && jtr.Spec.RenewBefore.Duration >= expiry {
+		logger.Info("Token renewal requires an expiry longer than renewBefore", "expiry", jtr.Spec.Expiry, "renewBefore", jtr.Spec.RenewBefore.Duration)
+		reconcileFailureMessage = "Invalid renewBefore"
+		return ctrl.Result{}, nil
+	}
+
+	if conditions.IsFalse(&jtr, km.JoinTokenRequestTokenValidCondition) {
+		logger.Info("Token invalidated, maximum number of joins reached")
+		r.unwatchJoins(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
+	now := time.Now()
+	retryJoins := false
+	if !tracksJoins(&jtr, now) {
+		r.unwatchJoins(req.NamespacedName)
+	} else {
+		if err := r.reconcileJoinedNodes(ctx, &jtr, types.NamespacedName{Name: jtr.Spec.ClusterName, Namespace: clusterNamespace}); err != nil {
+			// The hosted cluster may not be reachable yet, the joins are checked again later.
+			logger.Error(err, "Failed to check the nodes joined with the token")
+			retryJoins = true
+		}
+		if maxJoinsReached(&jtr) {
+			r.unwatchJoins(req.NamespacedName)
+			logger.Info("Invalidating token, maximum number of joins reached", "maxJoins", *jtr.Spec.MaxJoins)
+			for _, tokenID := range []string{jtr.Status.TokenID, jtr.Status.PreviousTokenID} {
+				if tokenID == "" {
+					continue
+				}
+				if err := r.invalidateToken(ctx, tokenID, pod); err != nil {
+					reconcileFailureMessage = "Failed invalidating token"
+					return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
+				}
+			}
+			jtr.Status.PreviousTokenID = ""
+			conditions.Set(&jtr, metav1.Condition{
+				Type:    km.JoinTokenRequestTokenValidCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  km.JoinTokenRequestMaxJoinsReachedReason,
+				Message: fmt.Sprintf("%d nodes joined the cluster with the token", len(jtr.Status.JoinedNodes)),
+			})
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if jtr.Status.PreviousTokenID != "" && !now.Before(previousTokenInvalidationTime(&jtr)) {
+		logger.Info("Invalidating previous token", "tokenID", jtr.Status.PreviousTokenID)
+		if err := r.invalidateToken(ctx, jtr.Status.PreviousTokenID, pod); err != nil {
+			reconcileFailureMessage = "Failed invalidating previous token"
+			return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
+		}
+		jtr.Status.PreviousTokenID = ""
+	}
+
+	if jtr.Status.TokenID != "" {
+		renewAt, ok := renewalTime(&jtr, expiry)
+		if !ok {
+			logger.Info("Already reconciled")
+			return ctrl.Result{RequeueAfter: requeueAfter(&jtr, expiry, now, retryJoins)}, nil
+		}
+		if now.Before(renewAt) {
+			return ctrl.Result{RequeueAfter: requeueAfter(&jtr, expiry, now, retryJoins)}, nil
+		}
+		logger.Info("Renewing token", "tokenID", jtr.Status.TokenID)
+	}
+
 	var cluster km.Cluster
 	err = r.Client.Get(ctx, types.NamespacedName{Name: jtr.Spec.ClusterName, Namespace: clusterNamespace}, &cluster)
 	if err != nil {
@@ -181,7 +268,7 @@ func (r *JoinTokenRequestReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
 	}
 
-	if cluster.Spec.Ingress != nil {
+	if cluster.Spec.GetIngressEndpoint() != nil {
 		token, err = updateJoinTokenURL(token, cluster)
 		if err != nil {
 			reconcileFailureMessage = "Failed updating token URL"
@@ -200,17 +287,102 @@ func (r *JoinTokenRequestReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
 	}
 
+	if jtr.Status.TokenID != "" {
+		// The Secret holds the new token at this point, the replaced one stays valid during the grace period. A token
+		// still waiting for its invalidation is invalidated right away, only the last replaced token is tracked.
+		if jtr.Status.PreviousTokenID != "" {
+			if err := r.invalidateToken(ctx, jtr.Status.PreviousTokenID, pod); err != nil {
+				logger.Error(err, "Failed to invalidate previous token", "tokenID", jtr.Status.PreviousTokenID)
+			}
+		}
+		jtr.Status.PreviousTokenID = jtr.Status.TokenID
+		jtr.Status.LastRotationTime = &metav1.Time{Time: now}
+	}
 	jtr.Status.TokenID = tokenID
+	jtr.Status.ExpiresAt = nil
+	if expiry > 0 {
+		jtr.Status.ExpiresAt = &metav1.Time{Time: now.Add(expiry)}
+	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter(&jtr, expiry, now, retryJoins)}, nil
 }
 
-func (r *JoinTokenRequestReconciler) invalidateToken(ctx context.Context, jtr *km.JoinTokenRequest, pod *v1.Pod) error {
-	cmd := fmt.Sprintf("k0s token invalidate %s", jtr.Status.TokenID)
+func (r *JoinTokenRequestReconciler) invalidateToken(ctx context.Context, tokenID string, pod *v1.Pod) error {
+	cmd := fmt.Sprintf("k0s token invalidate %s", tokenID)
 	_, err := exec.PodExecCmdOutput(ctx, r.ClientSet, r.RESTConfig, pod.Name, pod.Namespace, cmd)
 	return err
 }
 
+// parseExpiry parses the expiry of the token, zero meaning the token does not expire.
+func parseExpiry(expiry string) (time.Duration, error) {
+	if expiry == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(expiry)
+}
+
+// renewalTime returns the time the token of the JoinTokenRequest is renewed at, and false if it is never renewed.
+func renewalTime(jtr *km.JoinTokenRequest, expiry time.Duration) (time.Time, bool) {
+	if jtr.Spec.RenewBefore == nil || expiry <= 0 {
+		return time.Time{}, false
+	}
+	// Tokens created before the renewal was enabled have no recorded expiration time, they were created along with
+	// the JoinTokenRequest.
+	expiresAt := jtr.CreationTimestamp.Add(expiry)
+	if jtr.Status.ExpiresAt != nil {
+		expiresAt = jtr.Status.ExpiresAt.Time
+	}
+	return expiresAt.Add(-jtr.Spec.RenewBefore.Duration), true
+}
+
+// previousTokenInvalidationTime returns the time the token replaced by the last renewal is invalidated at.
+func previousTokenInvalidationTime(jtr *km.JoinTokenRequest) time.Time {
+	gracePeriod := joinTokenRotationGracePeriod
+	if jtr.Spec.RenewBefore != nil {
+		gracePeriod = min(gracePeriod, jtr.Spec.RenewBefore.Duration)
+	}
+	if jtr.Status.LastRotationTime == nil {
+		return time.Time{}
+	}
+	return jtr.Status.LastRotationTime.Add(gracePeriod)
+}
+
+// requeueAfter returns the time until the JoinTokenRequest is reconciled again, zero if it is not. The joins are
+// tracked until the token expires, and checked again shortly if they could not be.
+func requeueAfter(jtr *km.JoinTokenRequest, expiry time.Duration, now time.Time, retryJoins bool) time.Duration {
+	next := nextReconcileAfter(jtr, expiry, now)
+	if !tracksJoins(jtr, now) {
+		return next
+	}
+	var joinsAfter time.Duration
+	switch {
+	case retryJoins:
+		joinsAfter = joinWatchRetryInterval
+	case jtr.Status.ExpiresAt != nil && now.Before(jtr.Status.ExpiresAt.Time):
+		joinsAfter = jtr.Status.ExpiresAt.Sub(now)
+	}
+	if joinsAfter > 0 && (next == 0 || joinsAfter < next) {
+		next = joinsAfter
+	}
+	return next
+}
+
+// nextReconcileAfter returns the time until the next renewal of the token or invalidation of the previous token,
+// zero if none is pending.
+func nextReconcileAfter(jtr *km.JoinTokenRequest, expiry time.Duration, now time.Time) time.Duration {
+	var next time.Duration
+	if renewAt, ok := renewalTime(jtr, expiry); ok {
+		next = max(renewAt.Sub(now), time.Second)
+	}
+	if jtr.Status.PreviousTokenID != "" {
+		invalidateAfter := max(previousTokenInvalidationTime(jtr).Sub(now), time.Second)
+		if next == 0 || invalidateAfter < next {
+			next = invalidateAfter
+		}
+	}
+	return next
+}
+
 func (r *JoinTokenRequestReconciler) reconcileSecret(ctx context.Context, jtr km.JoinTokenRequest, token string) error {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling configmap")
@@ -299,10 +471,21 @@ func ensureOwnerReference(jtr *km.JoinTokenRequest, kmc km.Cluster) bool {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *JoinTokenRequestReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&km.JoinTokenRequest{}).
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+	r.controller = c
+
+	// The watches of the hosted clusters are stopped along with the manager.
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		r.stopJoinWatches()
+		return nil
+	}))
 }
 
 func updateJoinTokenURL(token string, kmc km.Cluster) (string, error) {
@@ -317,7 +500,7 @@ func updateJoinTokenURL(token string, kmc km.Cluster) (string, error) {
 	}
 
 	for _, cluster := range cfg.Clusters {
-		cluster.Server = fmt.Sprintf("https://%s:%d", kmc.Spec.Ingress.APIHost, kmc.Spec.Ingress.Port)
+		cluster.Server = util.ServerURL(kmc.Spec.GetIngressEndpoint().APIHost, kmc.Spec.GetIngressEndpoint().Port)
 	}
 
 	updatedData, err := clientcmd.Write(*cfg)
@@ -0,0 +1,77 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+func TestJoinTokenRenewal(t *testing.T) {
+	now := time.Now()
+	created := now.Add(-20 * time.Hour)
+	jtr := &km.JoinTokenRequest{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec:       km.JoinTokenRequestSpec{Expiry: "24h"},
+		Status:     km.JoinTokenRequestStatus{TokenID: "abcdef"},
+	}
+	expiry, err := parseExpiry(jtr.Spec.Expiry)
+	assert.NoError(t, err)
+
+	// Without renewBefore the token is never renewed.
+	_, ok := renewalTime(jtr, expiry)
+	assert.False(t, ok)
+	assert.Zero(t, nextReconcileAfter(jtr, expiry, now))
+
+	// A token without recorded expiration time expires relative to the creation of the JoinTokenRequest.
+	jtr.Spec.RenewBefore = &metav1.Duration{Duration: 2 * time.Hour}
+	renewAt, ok := renewalTime(jtr, expiry)
+	assert.True(t, ok)
+	assert.Equal(t, created.Add(22*time.Hour), renewAt)
+	assert.Equal(t, 2*time.Hour, nextReconcileAfter(jtr, expiry, now))
+
+	jtr.Status.ExpiresAt = &metav1.Time{Time: now.Add(time.Hour)}
+	renewAt, ok = renewalTime(jtr, expiry)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(-time.Hour), renewAt)
+	// An overdue renewal is retried shortly.
+	assert.Equal(t, time.Second, nextReconcileAfter(jtr, expiry, now))
+
+	// Once renewed, the previous token is invalidated after the grace period, before the renewal of the new token.
+	jtr.Status.ExpiresAt = &metav1.Time{Time: now.Add(expiry)}
+	jtr.Status.PreviousTokenID = "ghijkl"
+	jtr.Status.LastRotationTime = &metav1.Time{Time: now}
+	assert.Equal(t, now.Add(joinTokenRotationGracePeriod), previousTokenInvalidationTime(jtr))
+	assert.Equal(t, joinTokenRotationGracePeriod, nextReconcileAfter(jtr, expiry, now))
+
+	// The grace period does not outlive the previous token.
+	jtr.Spec.RenewBefore = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, now.Add(time.Minute), previousTokenInvalidationTime(jtr))
+
+	expiry, err = parseExpiry("")
+	assert.NoError(t, err)
+	assert.Zero(t, expiry)
+	_, ok = renewalTime(jtr, expiry)
+	assert.False(t, ok)
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	autopilot "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+const (
+	// joinWatchRetryInterval is the interval the hosted cluster is watched again at when it could not be watched.
+	joinWatchRetryInterval = 30 * time.Second
+	// joinListTimeout bounds the time waited for the watch of the hosted cluster to be synced.
+	joinListTimeout = 30 * time.Second
+	// bootstrapUserPrefix is the prefix of the user name the kubelets authenticate as with a bootstrap token.
+	bootstrapUserPrefix = "system:bootstrap:"
+	// nodeUserPrefix is the prefix of the common name of the kubelet client certificates.
+	nodeUserPrefix = "system:node:"
+	// workerRole is the role of the tokens used to join worker nodes.
+	workerRole = "worker"
+)
+
+// hostedClusterScheme is the scheme of the objects watched in the hosted clusters to track the joins.
+var hostedClusterScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(hostedClusterScheme))
+	utilruntime.Must(autopilot.AddToScheme(hostedClusterScheme))
+}
+
+// joinWatch is a cache of a hosted cluster, shared by the JoinTokenRequests tracking the joins to the cluster. Changes
+// of the watched objects trigger the reconciliation of the JoinTokenRequests.
+type joinWatch struct {
+	cache  cache.Cache
+	cancel context.CancelFunc
+	// roles are the roles of the tokens whose joins are watched.
+	roles map[string]struct{}
+	// requests are the JoinTokenRequests tracking their joins with the watch.
+	requests map[client.ObjectKey]struct{}
+}
+
+// tracksJoins returns true if the nodes joined with the token of the JoinTokenRequest are tracked, which is requested
+// with trackJoins or maxJoins.
+func tracksJoins(jtr *km.JoinTokenRequest, now time.Time) bool {
+	if !jtr.Spec.TrackJoins && jtr.Spec.MaxJoins == nil {
+		return false
+	}
+	if jtr.Status.TokenID == "" {
+		return false
+	}
+	return jtr.Status.ExpiresAt == nil || now.Before(jtr.Status.ExpiresAt.Time) || jtr.Status.PreviousTokenID != ""
+}
+
+// reconcileJoinedNodes records the nodes which joined the hosted cluster with the tokens of the JoinTokenRequest. Worker
+// joins are identified by the certificate signing requests of their kubelets, and controller joins by the ControlNodes
+// they register.
+func (r *JoinTokenRequestReconciler) reconcileJoinedNodes(ctx context.Context, jtr *km.JoinTokenRequest, cluster client.ObjectKey) error {
+	hostedCache, err := r.watchJoins(ctx, jtr, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to watch workload cluster: %w", err)
+	}
+
+	// The cache blocks until it is synced, which never happens while the hosted cluster is unreachable.
+	listCtx, cancel := context.WithTimeout(ctx, joinListTimeout)
+	defer cancel()
+	if jtr.Spec.Role != workerRole {
+		var controlNodes autopilot.ControlNodeList
+		if err := hostedCache.List(listCtx, &controlNodes); err != nil {
+			return fmt.Errorf("failed to list control nodes: %w", err)
+		}
+		r.recordJoinedNodes(jtr, controlNodeJoins(jtr, controlNodes.Items))
+		return nil
+	}
+
+	var csrs certificatesv1.CertificateSigningRequestList
+	if err := hostedCache.List(listCtx, &csrs); err != nil {
+		return fmt.Errorf("failed to list certificate signing requests: %w", err)
+	}
+	r.recordJoinedNodes(jtr, csrJoins(jtr, csrs.Items))
+	return nil
+}
+
+// watchJoins returns the cache of the hosted cluster, which is created and started on the first call for the cluster.
+// The objects the joins of the role of the JoinTokenRequest are tracked with are watched on the first call for the
+// role, and the JoinTokenRequest is reconciled on each of their changes until unwatchJoins is called for it.
+func (r *JoinTokenRequestReconciler) watchJoins(ctx context.Context, jtr *km.JoinTokenRequest, cluster client.ObjectKey) (cache.Cache, error) {
+	r.joinWatchesMu.Lock()
+	defer r.joinWatchesMu.Unlock()
+
+	w, ok := r.joinWatches[cluster]
+	if !ok {
+		restConfig, err := remote.RESTConfig(ctx, "k0smotron", r.Client, cluster)
+		if err != nil {
+			return nil, err
+		}
+		hostedCache, err := cache.New(restConfig, cache.Options{Scheme: hostedClusterScheme})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cache: %w", err)
+		}
+
+		// The cache outlives the reconciliation, it is stopped once no JoinTokenRequest of the cluster tracks its joins.
+		watchCtx, cancel := context.WithCancel(context.Background())
+		w = &joinWatch{
+			cache:    hostedCache,
+			cancel:   cancel,
+			roles:    map[string]struct{}{},
+			requests: map[client.ObjectKey]struct{}{},
+		}
+		logger := log.FromContext(ctx)
+		go func() {
+			if err := hostedCache.Start(watchCtx); err != nil {
+				logger.Error(err, "Failed to watch workload cluster", "cluster", cluster)
+			}
+		}()
+
+		if r.joinWatches == nil {
+			r.joinWatches = map[client.ObjectKey]*joinWatch{}
+		}
+		r.joinWatches[cluster] = w
+	}
+
+	if _, ok := w.roles[jtr.Spec.Role]; !ok {
+		var obj client.Object = &certificatesv1.CertificateSigningRequest{}
+		if jtr.Spec.Role != workerRole {
+			obj = &autopilot.ControlNode{}
+		}
+		err := r.controller.Watch(source.Kind(w.cache, obj, handler.EnqueueRequestsFromMapFunc(r.joinWatchRequests(cluster))))
+		if err != nil {
+			if len(w.requests) == 0 {
+				w.cancel()
+				delete(r.joinWatches, cluster)
+			}
+			return nil, err
+		}
+		w.roles[jtr.Spec.Role] = struct{}{}
+	}
+
+	w.requests[client.ObjectKeyFromObject(jtr)] = struct{}{}
+	return w.cache, nil
+}
+
+// joinWatchRequests maps the changes of the objects watched in the hosted cluster to the JoinTokenRequests tracking
+// their joins to the cluster.
+func (r *JoinTokenRequestReconciler) joinWatchRequests(cluster client.ObjectKey) handler.MapFunc {
+	return func(_ context.Context, _ client.Object) []ctrl.Request {
+		r.joinWatchesMu.Lock()
+		defer r.joinWatchesMu.Unlock()
+
+		w, ok := r.joinWatches[cluster]
+		if !ok {
+			return nil
+		}
+		requests := make([]ctrl.Request, 0, len(w.requests))
+		for jtr := range w.requests {
+			requests = append(requests, ctrl.Request{NamespacedName: jtr})
+		}
+		return requests
+	}
+}
+
+// unwatchJoins stops the tracking of the joins of the JoinTokenRequest, and stops the watch of its hosted cluster
+// once no other JoinTokenRequest tracks its joins.
+func (r *JoinTokenRequestReconciler) unwatchJoins(jtr client.ObjectKey) {
+	r.joinWatchesMu.Lock()
+	defer r.joinWatchesMu.Unlock()
+
+	for cluster, w := range r.joinWatches {
+		if _, ok := w.requests[jtr]; !ok {
+			continue
+		}
+		delete(w.requests, jtr)
+		if len(w.requests) == 0 {
+			w.cancel()
+			delete(r.joinWatches, cluster)
+		}
+	}
+}
+
+// stopJoinWatches stops the watches of all the hosted clusters.
+func (r *JoinTokenRequestReconciler) stopJoinWatches() {
+	r.joinWatchesMu.Lock()
+	defer r.joinWatchesMu.Unlock()
+
+	for cluster, w := range r.joinWatches {
+		w.cancel()
+		delete(r.joinWatches, cluster)
+	}
+}
+
+// csrJoins returns the worker nodes which got a kubelet client certificate issued with the tokens of the
+// JoinTokenRequest.
+func csrJoins(jtr *km.JoinTokenRequest, csrs []certificatesv1.CertificateSigningRequest) []km.JoinedNode {
+	tokenIDs := []string{jtr.Status.TokenID}
+	if jtr.Status.PreviousTokenID != "" {
+		tokenIDs = append(tokenIDs, jtr.Status.PreviousTokenID)
+	}
+
+	var joins []km.JoinedNode
+	for _, csr := range csrs {
+		tokenID, ok := strings.CutPrefix(csr.Spec.Username, bootstrapUserPrefix)
+		if !ok || !slices.Contains(tokenIDs, tokenID) || len(csr.Status.Certificate) == 0 {
+			continue
+		}
+		nodeName, err := csrNodeName(csr.Spec.Request)
+		if err != nil {
+			continue
+		}
+		joins = append(joins, km.JoinedNode{Name: nodeName, TokenID: tokenID, JoinTime: csr.CreationTimestamp})
+	}
+	return joins
+}
+
+// controlNodeJoins returns the controllers which registered their ControlNode while a token of the JoinTokenRequest
+// was valid. k0s does not record the token a controller joined with, so each controller joining in the meantime is
+// attributed to the token valid at the time, including the controllers joined with other tokens. The joins of
+// controller tokens are only reported, maxJoins is rejected for them.
+func controlNodeJoins(jtr *km.JoinTokenRequest, controlNodes []autopilot.ControlNode) []km.JoinedNode {
+	var joins []km.JoinedNode
+	for _, cn := range controlNodes {
+		joined := cn.CreationTimestamp
+		if joined.Before(&jtr.CreationTimestamp) || (jtr.Status.ExpiresAt != nil && !joined.Before(jtr.Status.ExpiresAt)) {
+			continue
+		}
+
+		// The controllers registered before the last renewal joined with the previous token.
+		tokenID := jtr.Status.TokenID
+		if jtr.Status.LastRotationTime != nil && joined.Before(jtr.Status.LastRotationTime) {
+			if jtr.Status.PreviousTokenID == "" {
+				continue
+			}
+			tokenID = jtr.Status.PreviousTokenID
+		}
+		joins = append(joins, km.JoinedNode{Name: cn.Name, TokenID: tokenID, JoinTime: joined})
+	}
+	return joins
+}
+
+// recordJoinedNodes adds the joined nodes which are not recorded yet to the status of the JoinTokenRequest, and
+// records an event for each of them.
+func (r *JoinTokenRequestReconciler) recordJoinedNodes(jtr *km.JoinTokenRequest, joins []km.JoinedNode) {
+	// The joins are found in no particular order, the nodes are recorded in the order they joined.
+	slices.SortFunc(joins, func(a, b km.JoinedNode) int {
+		return a.JoinTime.Compare(b.JoinTime.Time)
+	})
+	for _, join := range joins {
+		if slices.ContainsFunc(jtr.Status.JoinedNodes, func(n km.JoinedNode) bool { return n.Name == join.Name }) {
+			continue
+		}
+
+		jtr.Status.JoinedNodes = append(jtr.Status.JoinedNodes, join)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(jtr, v1.EventTypeNormal, "NodeJoined", "Node %s joined the cluster with token %s", join.Name, join.TokenID)
+		}
+	}
+}
+
+// maxJoinsReached returns true if the number of nodes joined with the tokens of the JoinTokenRequest reached its maximum.
+// The controller joins are attributed to a token on a best effort basis, they never invalidate it.
+func maxJoinsReached(jtr *km.JoinTokenRequest) bool {
+	if jtr.Spec.Role != workerRole {
+		return false
+	}
+	return jtr.Spec.MaxJoins != nil && len(jtr.Status.JoinedNodes) >= int(*jtr.Spec.MaxJoins)
+}
+
+// csrNodeName returns the name of the node requesting a kubelet client certificate with the given PEM encoded request.
+func csrNodeName(request []byte) (string, error) {
+	block, _ := pem.Decode(request)
+	if block == nil {
+		return "", fmt.Errorf("failed to decode certificate signing request")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	nodeName, ok := strings.CutPrefix(csr.Subject.CommonName, nodeUserPrefix)
+	if !ok || nodeName == "" {
+		return "", fmt.Errorf("unexpected common name %q", csr.Subject.CommonName)
+	}
+	return nodeName, nil
+}
@@ -0,0 +1,185 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	autopilot "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+func kubeletCSR(t *testing.T, nodeName, tokenID string, created time.Time, issued bool) certificatesv1.CertificateSigningRequest {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: nodeUserPrefix + nodeName, Organization: []string{"system:nodes"}},
+	}, key)
+	require.NoError(t, err)
+
+	csr := certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "csr-" + nodeName, CreationTimestamp: metav1.NewTime(created)},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+			Username: bootstrapUserPrefix + tokenID,
+		},
+	}
+	if issued {
+		csr.Status.Certificate = []byte("certificate")
+	}
+	return csr
+}
+
+func TestRecordJoinedNodes(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	recorder := record.NewFakeRecorder(10)
+	r := &JoinTokenRequestReconciler{Recorder: recorder}
+	jtr := &km.JoinTokenRequest{
+		Spec:   km.JoinTokenRequestSpec{Role: workerRole, MaxJoins: new(int32(2))},
+		Status: km.JoinTokenRequestStatus{TokenID: "abcdef", PreviousTokenID: "ghijkl"},
+	}
+	assert.True(t, tracksJoins(jtr, now))
+
+	r.recordJoinedNodes(jtr, csrJoins(jtr, []certificatesv1.CertificateSigningRequest{
+		kubeletCSR(t, "worker-1", "abcdef", now, true),
+		kubeletCSR(t, "worker-0", "ghijkl", now.Add(-time.Minute), true),
+		// Pending requests and requests of other tokens are ignored.
+		kubeletCSR(t, "worker-2", "abcdef", now, false),
+		kubeletCSR(t, "worker-3", "mnopqr", now, true),
+	}))
+	assert.Equal(t, []km.JoinedNode{
+		{Name: "worker-0", TokenID: "ghijkl", JoinTime: metav1.NewTime(now.Add(-time.Minute))},
+		{Name: "worker-1", TokenID: "abcdef", JoinTime: metav1.NewTime(now)},
+	}, jtr.Status.JoinedNodes)
+	assert.True(t, maxJoinsReached(jtr))
+	require.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Normal NodeJoined Node worker-0 joined the cluster with token ghijkl", <-recorder.Events)
+
+	// The nodes already recorded are not recorded again.
+	r.recordJoinedNodes(jtr, csrJoins(jtr, []certificatesv1.CertificateSigningRequest{kubeletCSR(t, "worker-1", "abcdef", now, true)}))
+	assert.Len(t, jtr.Status.JoinedNodes, 2)
+	assert.Len(t, recorder.Events, 1)
+
+	// The joins are not tracked once the token expired.
+	jtr.Status.PreviousTokenID = ""
+	jtr.Status.ExpiresAt = &metav1.Time{Time: now}
+	assert.False(t, tracksJoins(jtr, now))
+
+	// Without maxJoins, the joins are only tracked on request.
+	jtr.Status.ExpiresAt = nil
+	jtr.Spec.MaxJoins = nil
+	assert.False(t, tracksJoins(jtr, now))
+	jtr.Spec.TrackJoins = true
+	assert.True(t, tracksJoins(jtr, now))
+}
+
+func TestControlNodeJoins(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	controlNode := func(name string, created time.Time) autopilot.ControlNode {
+		return autopilot.ControlNode{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+	}
+	jtr := &km.JoinTokenRequest{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+		Spec:       km.JoinTokenRequestSpec{Role: "controller", TrackJoins: true},
+		Status: km.JoinTokenRequestStatus{
+			TokenID:          "abcdef",
+			PreviousTokenID:  "ghijkl",
+			LastRotationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			ExpiresAt:        &metav1.Time{Time: now.Add(time.Hour)},
+		},
+	}
+	assert.True(t, tracksJoins(jtr, now))
+
+	controlNodes := []autopilot.ControlNode{
+		// The controllers registered before the JoinTokenRequest did not join with its tokens.
+		controlNode("controller-0", now.Add(-2*time.Hour)),
+		controlNode("controller-1", now.Add(-30*time.Minute)),
+		controlNode("controller-2", now),
+	}
+	assert.Equal(t, []km.JoinedNode{
+		{Name: "controller-1", TokenID: "ghijkl", JoinTime: metav1.NewTime(now.Add(-30 * time.Minute))},
+		{Name: "controller-2", TokenID: "abcdef", JoinTime: metav1.NewTime(now)},
+	}, controlNodeJoins(jtr, controlNodes))
+
+	// Once the previous token is invalidated, only the controllers registered since the renewal are attributed.
+	jtr.Status.PreviousTokenID = ""
+	assert.Equal(t, []km.JoinedNode{
+		{Name: "controller-2", TokenID: "abcdef", JoinTime: metav1.NewTime(now)},
+	}, controlNodeJoins(jtr, controlNodes))
+
+	// Controllers joined with other tokens may be attributed, so they never invalidate the token.
+	r := &JoinTokenRequestReconciler{}
+	r.recordJoinedNodes(jtr, controlNodeJoins(jtr, controlNodes))
+	jtr.Spec.MaxJoins = new(int32(1))
+	assert.False(t, maxJoinsReached(jtr))
+}
+
+func TestJoinTrackingRequeue(t *testing.T) {
+	now := time.Now()
+	jtr := &km.JoinTokenRequest{
+		Spec:   km.JoinTokenRequestSpec{Role: workerRole, TrackJoins: true},
+		Status: km.JoinTokenRequestStatus{TokenID: "abcdef"},
+	}
+
+	// The joins of a token which does not expire are watched without polling.
+	assert.Zero(t, requeueAfter(jtr, 0, now, false))
+	assert.Equal(t, joinWatchRetryInterval, requeueAfter(jtr, 0, now, true))
+
+	// The watch is stopped once the token expired.
+	jtr.Status.ExpiresAt = &metav1.Time{Time: now.Add(time.Hour)}
+	assert.Equal(t, time.Hour, requeueAfter(jtr, time.Hour, now, false))
+	jtr.Status.ExpiresAt = &metav1.Time{Time: now}
+	assert.Zero(t, requeueAfter(jtr, time.Hour, now, false))
+}
+
+func TestUnwatchJoins(t *testing.T) {
+	cancelled := 0
+	cancel := func() { cancelled++ }
+	cluster := client.ObjectKey{Namespace: "default", Name: "my-cluster"}
+	first := client.ObjectKey{Namespace: "default", Name: "first"}
+	second := client.ObjectKey{Namespace: "default", Name: "second"}
+	r := &JoinTokenRequestReconciler{joinWatches: map[client.ObjectKey]*joinWatch{
+		cluster: {cancel: cancel, requests: map[client.ObjectKey]struct{}{first: {}, second: {}}},
+	}}
+
+	// The watch of the cluster is stopped once no JoinTokenRequest tracks its joins.
+	r.unwatchJoins(first)
+	assert.Zero(t, cancelled)
+	assert.Equal(t, []ctrl.Request{{NamespacedName: second}}, r.joinWatchRequests(cluster)(context.Background(), nil))
+	r.unwatchJoins(second)
+	assert.Equal(t, 1, cancelled)
+	assert.Empty(t, r.joinWatches)
+	assert.Empty(t, r.joinWatchRequests(cluster)(context.Background(), nil))
+}
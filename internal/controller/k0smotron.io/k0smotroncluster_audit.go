@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"path"
+	"strconv"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+const (
+	auditPolicyDir         = "/etc/k0smotron/audit/policy"
+	auditPolicyFile        = "policy.yaml"
+	auditPolicyVolumeName  = "audit-policy"
+	auditWebhookDir        = "/etc/k0smotron/audit/webhook"
+	auditWebhookFile       = "kubeconfig"
+	auditWebhookVolumeName = "audit-webhook"
+	// auditLogPath is on the k0s data volume, so the audit log is kept as long as the k0s data is.
+	auditLogPath = "/var/lib/k0s/audit/audit.log"
+)
+
+// auditAPIServerArgs returns the kube-apiserver flags configuring audit logging.
+func auditAPIServerArgs(audit *km.AuditSpec) map[string]any {
+	args := map[string]any{
+		"audit-policy-file": path.Join(auditPolicyDir, auditPolicyFile),
+	}
+
+	if audit.Log != nil {
+		args["audit-log-path"] = auditLogPath
+		args["audit-log-maxsize"] = strconv.Itoa(int(audit.Log.MaxSize))
+		args["audit-log-maxbackup"] = strconv.Itoa(int(audit.Log.MaxBackups))
+		args["audit-log-maxage"] = strconv.Itoa(int(audit.Log.MaxAge))
+	}
+
+	if audit.Webhook != nil {
+		args["audit-webhook-config-file"] = path.Join(auditWebhookDir, auditWebhookFile)
+		if audit.Webhook.Mode != "" {
+			args["audit-webhook-mode"] = audit.Webhook.Mode
+		}
+		if audit.Webhook.InitialBackoff != nil {
+			args["audit-webhook-initial-backoff"] = audit.Webhook.InitialBackoff.Duration.String()
+		}
+	}
+
+	return args
+}
+
+// addAudit mounts the audit policy and the webhook configuration into the controller container, and adds the
+// sidecar streaming the audit log if requested.
+func addAudit(kmc *km.Cluster, statefulSet *apps.StatefulSet) {
+	audit := kmc.Spec.Audit
+
+	statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, v1.Volume{
+		Name: auditPolicyVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: audit.Policy.Name},
+				Items: []v1.KeyToPath{{
+					Key:  audit.Policy.Key,
+					Path: auditPolicyFile,
+				}},
+			},
+		},
+	})
+	statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+		Name:      auditPolicyVolumeName,
+		MountPath: auditPolicyDir,
+		ReadOnly:  true,
+	})
+
+	if audit.Webhook != nil {
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, v1.Volume{
+			Name: auditWebhookVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: audit.Webhook.ConfigSecretRef.Name,
+					Items: []v1.KeyToPath{{
+						Key:  audit.Webhook.ConfigSecretRef.Key,
+						Path: auditWebhookFile,
+					}},
+				},
+			},
+		})
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+			Name:      auditWebhookVolumeName,
+			MountPath: auditWebhookDir,
+			ReadOnly:  true,
+		})
+	}
+
+	if audit.Log != nil && audit.Log.StreamToStdout {
+		// The k0s image is already present on the node and ships a tail supporting -F, which follows the log
+		// file across rotations.
+		statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, v1.Container{
+			Name:            "audit-log",
+			Image:           kmc.Spec.GetImage(),
+			ImagePullPolicy: v1.PullIfNotPresent,
+			Command:         []string{"tail", "-n", "0", "-F", auditLogPath},
+			VolumeMounts: []v1.VolumeMount{{
+				Name:      k0sDataVolumeName(kmc),
+				MountPath: "/var/lib/k0s",
+				ReadOnly:  true,
+			}},
+		})
+	}
+}
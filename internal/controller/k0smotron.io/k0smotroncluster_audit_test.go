@@ -0,0 +1,115 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+func TestAuditAPIServerArgs(t *testing.T) {
+	args := auditAPIServerArgs(&km.AuditSpec{
+		Policy: km.AuditPolicyRef{Name: "audit-policy", Key: "policy.yaml"},
+		Log:    &km.AuditLogBackend{MaxSize: 100, MaxBackups: 10, MaxAge: 30},
+		Webhook: &km.AuditWebhookBackend{
+			ConfigSecretRef: km.AuditWebhookConfigRef{Name: "audit-webhook", Key: "kubeconfig"},
+			Mode:            "batch",
+			InitialBackoff:  &metav1.Duration{Duration: 10 * time.Second},
+		},
+	})
+
+	assert.Equal(t, map[string]any{
+		"audit-policy-file":             "/etc/k0smotron/audit/policy/policy.yaml",
+		"audit-log-path":                "/var/lib/k0s/audit/audit.log",
+		"audit-log-maxsize":             "100",
+		"audit-log-maxbackup":           "10",
+		"audit-log-maxage":              "30",
+		"audit-webhook-config-file":     "/etc/k0smotron/audit/webhook/kubeconfig",
+		"audit-webhook-mode":            "batch",
+		"audit-webhook-initial-backoff": "10s",
+	}, args)
+}
+
+func TestAddAudit(t *testing.T) {
+	newStatefulSet := func() *apps.StatefulSet {
+		sts := &apps.StatefulSet{}
+		sts.Spec.Template.Spec.Containers = []v1.Container{{Name: "controller"}}
+		return sts
+	}
+
+	t.Run("log backend streamed to stdout", func(t *testing.T) {
+		kmc := &km.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: km.ClusterSpec{
+				Persistence: km.PersistenceSpec{
+					Type:                  "pvc",
+					PersistentVolumeClaim: &km.PersistentVolumeClaim{ObjectMeta: km.ObjectMeta{Name: "k0s-data"}},
+				},
+				Audit: &km.AuditSpec{
+					Policy: km.AuditPolicyRef{Name: "audit-policy", Key: "audit.yaml"},
+					Log:    &km.AuditLogBackend{StreamToStdout: true},
+				},
+			},
+		}
+		sts := newStatefulSet()
+
+		addAudit(kmc, sts)
+
+		require.Len(t, sts.Spec.Template.Spec.Volumes, 1)
+		assert.Equal(t, "audit-policy", sts.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+		assert.Equal(t, "audit.yaml", sts.Spec.Template.Spec.Volumes[0].ConfigMap.Items[0].Key)
+		assert.Equal(t, "/etc/k0smotron/audit/policy", sts.Spec.Template.Spec.Containers[0].VolumeMounts[0].MountPath)
+
+		require.Len(t, sts.Spec.Template.Spec.Containers, 2)
+		sidecar := sts.Spec.Template.Spec.Containers[1]
+		assert.Equal(t, "audit-log", sidecar.Name)
+		assert.Equal(t, kmc.Spec.GetImage(), sidecar.Image)
+		assert.Equal(t, []string{"tail", "-n", "0", "-F", "/var/lib/k0s/audit/audit.log"}, sidecar.Command)
+		assert.Equal(t, "k0s-data", sidecar.VolumeMounts[0].Name)
+	})
+
+	t.Run("webhook backend", func(t *testing.T) {
+		kmc := &km.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: km.ClusterSpec{
+				Audit: &km.AuditSpec{
+					Policy:  km.AuditPolicyRef{Name: "audit-policy", Key: "policy.yaml"},
+					Webhook: &km.AuditWebhookBackend{ConfigSecretRef: km.AuditWebhookConfigRef{Name: "audit-webhook", Key: "config"}},
+				},
+			},
+		}
+		sts := newStatefulSet()
+
+		addAudit(kmc, sts)
+
+		require.Len(t, sts.Spec.Template.Spec.Volumes, 2)
+		assert.Equal(t, "audit-webhook", sts.Spec.Template.Spec.Volumes[1].Secret.SecretName)
+		assert.Equal(t, "config", sts.Spec.Template.Spec.Volumes[1].Secret.Items[0].Key)
+		assert.Equal(t, "/etc/k0smotron/audit/webhook", sts.Spec.Template.Spec.Containers[0].VolumeMounts[1].MountPath)
+		assert.Len(t, sts.Spec.Template.Spec.Containers, 1)
+	})
+}
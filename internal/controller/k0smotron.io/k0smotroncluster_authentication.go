@@ -0,0 +1,184 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+	kcontrollerutil "github.com/k0sproject/k0smotron/v2/internal/controller/util"
+)
+
+const (
+	authenticationConfigDir  = "/etc/k0smotron/authentication"
+	authenticationConfigFile = "authentication-config.yaml"
+	authenticationVolumeName = "authentication-config"
+)
+
+// authenticationConfigPath is the path of the AuthenticationConfiguration file in the controller container.
+var authenticationConfigPath = path.Join(authenticationConfigDir, authenticationConfigFile)
+
+func hasOIDCAuthentication(kmc *km.Cluster) bool {
+	return kmc.Spec.Authentication != nil && kmc.Spec.Authentication.OIDC != nil
+}
+
+// reconcileAuthenticationConfig renders the kube-apiserver AuthenticationConfiguration into a Secret, or removes the
+// Secret if no authentication is configured. The CA bundle is read from the management cluster.
+func (scope *kmcScope) reconcileAuthenticationConfig(ctx context.Context, kmc *km.Cluster, managementClusterClient client.Client) error {
+	if !hasOIDCAuthentication(kmc) {
+		s := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      kmc.GetAuthenticationConfigSecretName(),
+				Namespace: kmc.Namespace,
+			},
+		}
+		return client.IgnoreNotFound(scope.client.Delete(ctx, s))
+	}
+
+	var ca string
+	if src := kmc.Spec.Authentication.OIDC.CertificateAuthority; src != nil {
+		var err error
+		ca, err = getCertificateAuthority(ctx, managementClusterClient, kmc.Namespace, src)
+		if err != nil {
+			return err
+		}
+	}
+
+	s, err := generateAuthenticationConfigSecret(kmc, ca)
+	if err != nil {
+		return err
+	}
+
+	_ = kcontrollerutil.SetExternalOwnerReference(kmc, &s, scope.client.Scheme(), scope.externalOwner)
+
+	return scope.reconcileResource(ctx, kmc, &s)
+}
+
+// getCertificateAuthority reads the CA bundle from the referenced Secret or ConfigMap.
+func getCertificateAuthority(ctx context.Context, c client.Client, namespace string, src *km.CertificateAuthoritySource) (string, error) {
+	switch {
+	case src.SecretRef != nil:
+		s := &v1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: src.SecretRef.Name}, s); err != nil {
+			return "", fmt.Errorf("failed to get OIDC CA secret: %w", err)
+		}
+		ca, ok := s.Data[src.SecretRef.Key]
+		if !ok {
+			return "", fmt.Errorf("OIDC CA secret %s does not contain key %s", src.SecretRef.Name, src.SecretRef.Key)
+		}
+		return string(ca), nil
+	case src.ConfigMapRef != nil:
+		cm := &v1.ConfigMap{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: src.ConfigMapRef.Name}, cm); err != nil {
+			return "", fmt.Errorf("failed to get OIDC CA configmap: %w", err)
+		}
+		ca, ok := cm.Data[src.ConfigMapRef.Key]
+		if !ok {
+			return "", fmt.Errorf("OIDC CA configmap %s does not contain key %s", src.ConfigMapRef.Name, src.ConfigMapRef.Key)
+		}
+		return ca, nil
+	default:
+		return "", fmt.Errorf("OIDC certificateAuthority requires either secretRef or configMapRef")
+	}
+}
+
+func generateAuthenticationConfigSecret(kmc *km.Cluster, ca string) (v1.Secret, error) {
+	oidc := kmc.Spec.Authentication.OIDC
+
+	usernameClaim := oidc.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+
+	jwt := apiserverv1beta1.JWTAuthenticator{
+		Issuer: apiserverv1beta1.Issuer{
+			URL:                  oidc.IssuerURL,
+			Audiences:            []string{oidc.ClientID},
+			CertificateAuthority: ca,
+		},
+		ClaimMappings: apiserverv1beta1.ClaimMappings{
+			// Unlike the legacy flags, the AuthenticationConfiguration has no defaults and the prefix must always be set.
+			Username: apiserverv1beta1.PrefixedClaimOrExpression{
+				Claim:  usernameClaim,
+				Prefix: new(oidc.UsernamePrefix),
+			},
+		},
+	}
+	if oidc.GroupsClaim != "" {
+		jwt.ClaimMappings.Groups = apiserverv1beta1.PrefixedClaimOrExpression{
+			Claim:  oidc.GroupsClaim,
+			Prefix: new(oidc.GroupsPrefix),
+		}
+	}
+
+	authConfig := apiserverv1beta1.AuthenticationConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiserverv1beta1.ConfigSchemeGroupVersion.String(),
+			Kind:       "AuthenticationConfiguration",
+		},
+		JWT: []apiserverv1beta1.JWTAuthenticator{jwt},
+	}
+
+	b, err := yaml.Marshal(authConfig)
+	if err != nil {
+		return v1.Secret{}, fmt.Errorf("failed to marshal authentication configuration: %w", err)
+	}
+
+	return v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kmc.GetAuthenticationConfigSecretName(),
+			Namespace:   kmc.Namespace,
+			Labels:      kcontrollerutil.LabelsForK0smotronComponent(kmc, kcontrollerutil.ComponentAuthentication),
+			Annotations: kcontrollerutil.AnnotationsForK0smotronCluster(kmc),
+		},
+		Data: map[string][]byte{
+			authenticationConfigFile: b,
+		},
+	}, nil
+}
+
+// addAuthenticationConfig mounts the AuthenticationConfiguration into the controller container. The directory is
+// mounted instead of the file so that changes are propagated to the running pods, the kube-apiserver reloads the
+// file on changes.
+func addAuthenticationConfig(kmc *km.Cluster, statefulSet *apps.StatefulSet) {
+	statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, v1.Volume{
+		Name: authenticationVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: kmc.GetAuthenticationConfigSecretName(),
+			},
+		},
+	})
+	statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+		Name:      authenticationVolumeName,
+		MountPath: authenticationConfigDir,
+		ReadOnly:  true,
+	})
+}
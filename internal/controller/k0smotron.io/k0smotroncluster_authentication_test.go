@@ -0,0 +1,143 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+func TestGenerateAuthenticationConfigSecret(t *testing.T) {
+	kmc := &km.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: km.ClusterSpec{
+			Authentication: &km.AuthenticationSpec{
+				OIDC: &km.OIDCSpec{
+					IssuerURL:     "https://issuer.example.com",
+					ClientID:      "k0smotron",
+					UsernameClaim: "email",
+					GroupsClaim:   "groups",
+					GroupsPrefix:  "oidc:",
+				},
+			},
+		},
+	}
+
+	s, err := generateAuthenticationConfigSecret(kmc, "-----BEGIN CERTIFICATE-----\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, "kmc-test-authentication-config", s.Name)
+	assert.Equal(t, "authentication", s.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, `apiVersion: apiserver.config.k8s.io/v1beta1
+jwt:
+- claimMappings:
+    groups:
+      claim: groups
+      prefix: 'oidc:'
+    uid: {}
+    username:
+      claim: email
+      prefix: ""
+  issuer:
+    audiences:
+    - k0smotron
+    certificateAuthority: |
+      -----BEGIN CERTIFICATE-----
+    url: https://issuer.example.com
+kind: AuthenticationConfiguration
+`, string(s.Data["authentication-config.yaml"]))
+}
+
+func TestGetCertificateAuthority(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "oidc-ca", Namespace: "default"},
+			Data:       map[string][]byte{"ca.crt": []byte("secret-ca")},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "oidc-ca", Namespace: "default"},
+			Data:       map[string]string{"bundle.pem": "configmap-ca"},
+		},
+	).Build()
+
+	tests := []struct {
+		name    string
+		src     *km.CertificateAuthoritySource
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "secret",
+			src:  &km.CertificateAuthoritySource{SecretRef: &km.CAKeyRef{Name: "oidc-ca", Key: "ca.crt"}},
+			want: "secret-ca",
+		},
+		{
+			name: "configmap",
+			src:  &km.CertificateAuthoritySource{ConfigMapRef: &km.CAKeyRef{Name: "oidc-ca", Key: "bundle.pem"}},
+			want: "configmap-ca",
+		},
+		{
+			name:    "missing key",
+			src:     &km.CertificateAuthoritySource{SecretRef: &km.CAKeyRef{Name: "oidc-ca", Key: "missing"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing secret",
+			src:     &km.CertificateAuthoritySource{SecretRef: &km.CAKeyRef{Name: "missing", Key: "ca.crt"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca, err := getCertificateAuthority(context.Background(), c, "default", tt.src)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ca)
+		})
+	}
+}
+
+func TestAddAuthenticationConfig(t *testing.T) {
+	kmc := &km.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	sts := &apps.StatefulSet{}
+	sts.Spec.Template.Spec.Containers = []v1.Container{{Name: "controller"}}
+
+	addAuthenticationConfig(kmc, sts)
+
+	require.Len(t, sts.Spec.Template.Spec.Volumes, 1)
+	assert.Equal(t, "kmc-test-authentication-config", sts.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+	require.Len(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts, 1)
+	mount := sts.Spec.Template.Spec.Containers[0].VolumeMounts[0]
+	assert.Equal(t, "/etc/k0smotron/authentication", mount.MountPath)
+	assert.Empty(t, mount.SubPath, "the directory must be mounted for updates to be propagated")
+	assert.True(t, mount.ReadOnly)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+	kcontrollerutil "github.com/k0sproject/k0smotron/v2/internal/controller/util"
+)
+
+// defaultScaleUpPeriodSeconds is the period in which at most one control plane replica is added when no
+// scaling behavior is configured. Adding replicas one by one lets every new etcd member join before the next one.
+const defaultScaleUpPeriodSeconds = 60
+
+// reconcileAutoscaling creates the HorizontalPodAutoscaler scaling the Cluster through its scale subresource,
+// or removes it if autoscaling is disabled.
+func (scope *kmcScope) reconcileAutoscaling(ctx context.Context, kmc *km.Cluster) error {
+	if kmc.Spec.Autoscaling == nil {
+		// Most clusters never enable autoscaling, the HPA is only deleted if it exists.
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		err := scope.client.Get(ctx, client.ObjectKey{Name: kmc.GetAutoscalerName(), Namespace: kmc.Namespace}, hpa)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		return client.IgnoreNotFound(scope.client.Delete(ctx, hpa))
+	}
+
+	hpa := generateAutoscaler(kmc)
+
+	_ = kcontrollerutil.SetExternalOwnerReference(kmc, &hpa, scope.client.Scheme(), scope.externalOwner)
+
+	return scope.reconcileResource(ctx, kmc, &hpa)
+}
+
+func generateAutoscaler(kmc *km.Cluster) autoscalingv2.HorizontalPodAutoscaler {
+	as := kmc.Spec.Autoscaling
+
+	var metrics []autoscalingv2.MetricSpec
+	if as.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: v1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: as.TargetCPUUtilizationPercentage,
+				},
+			},
+		})
+	}
+	if as.TargetAPIServerRequestRate != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: as.TargetAPIServerRequestRate.MetricName},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &as.TargetAPIServerRequestRate.AverageValue,
+				},
+			},
+		})
+	}
+
+	behavior := as.Behavior
+	if behavior == nil {
+		behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleUp: &autoscalingv2.HPAScalingRules{
+				Policies: []autoscalingv2.HPAScalingPolicy{
+					{
+						Type:          autoscalingv2.PodsScalingPolicy,
+						Value:         1,
+						PeriodSeconds: defaultScaleUpPeriodSeconds,
+					},
+				},
+			},
+		}
+	}
+
+	minReplicas := max(as.MinReplicas, 1)
+
+	return autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2",
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kmc.GetAutoscalerName(),
+			Namespace:   kmc.Namespace,
+			Labels:      kcontrollerutil.LabelsForK0smotronComponent(kmc, kcontrollerutil.ComponentAutoscaling),
+			Annotations: kcontrollerutil.AnnotationsForK0smotronCluster(kmc),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: km.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       kmc.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: as.MaxReplicas,
+			Metrics:     metrics,
+			Behavior:    behavior,
+		},
+	}
+}
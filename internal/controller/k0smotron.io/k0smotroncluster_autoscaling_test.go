@@ -0,0 +1,101 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+func TestGenerateAutoscaler(t *testing.T) {
+	kmc := &km.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: km.ClusterSpec{
+			Autoscaling: &km.AutoscalingSpec{
+				MinReplicas:                    1,
+				MaxReplicas:                    5,
+				TargetCPUUtilizationPercentage: new(int32(70)),
+				TargetAPIServerRequestRate: &km.APIServerRequestRateTarget{
+					MetricName:   "apiserver_request_rate",
+					AverageValue: resource.MustParse("50"),
+				},
+			},
+		},
+	}
+
+	hpa := generateAutoscaler(kmc)
+
+	assert.Equal(t, "kmc-test", hpa.Name)
+	assert.Equal(t, "autoscaling", hpa.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "k0smotron.io/v1beta2",
+		Kind:       "Cluster",
+		Name:       "test",
+	}, hpa.Spec.ScaleTargetRef)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+
+	require.Len(t, hpa.Spec.Metrics, 2)
+	assert.Equal(t, v1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32(70), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, "apiserver_request_rate", hpa.Spec.Metrics[1].Pods.Metric.Name)
+	assert.Equal(t, "50", hpa.Spec.Metrics[1].Pods.Target.AverageValue.String())
+
+	// Without an explicit behavior, the control plane is scaled up one replica at a time.
+	require.NotNil(t, hpa.Spec.Behavior)
+	require.Len(t, hpa.Spec.Behavior.ScaleUp.Policies, 1)
+	assert.Equal(t, autoscalingv2.PodsScalingPolicy, hpa.Spec.Behavior.ScaleUp.Policies[0].Type)
+	assert.Equal(t, int32(1), hpa.Spec.Behavior.ScaleUp.Policies[0].Value)
+}
+
+func TestReconcileAutoscalingDisabled(t *testing.T) {
+	ctx := context.Background()
+	kmc := &km.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	deletes := 0
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			deletes++
+			return c.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+	scope := &kmcScope{client: c}
+
+	// Nothing is deleted when there is no HPA.
+	require.NoError(t, scope.reconcileAutoscaling(ctx, kmc))
+	assert.Zero(t, deletes)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: kmc.GetAutoscalerName(), Namespace: kmc.Namespace}}
+	require.NoError(t, c.Create(ctx, hpa))
+	require.NoError(t, scope.reconcileAutoscaling(ctx, kmc))
+	assert.Equal(t, 1, deletes)
+	assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(hpa), hpa))
+}
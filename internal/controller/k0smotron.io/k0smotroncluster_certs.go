@@ -20,11 +20,14 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"time"
 
+	apps "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/cloudflare/cfssl/cli/genkey"
 	"github.com/cloudflare/cfssl/csr"
@@ -37,7 +40,12 @@ import (
 	kutil "github.com/k0sproject/k0smotron/v2/internal/controller/util"
 )
 
+// etcdCertificateRenewalWindow is the time before expiry after which the etcd certificates are rotated automatically.
+const etcdCertificateRenewalWindow = 30 * 24 * time.Hour
+
 func (scope *kmcScope) ensureEtcdCertificates(ctx context.Context, kmc *km.Cluster) error {
+	logger := log.FromContext(ctx)
+
 	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
 	err := certificates.LookupCached(ctx, scope.secretCachingClient, scope.client, util.ObjectKey(kmc))
 	if err != nil {
@@ -76,8 +84,10 @@ func (scope *kmcScope) ensureEtcdCertificates(ctx context.Context, kmc *km.Clust
 		return fmt.Errorf("error looking up etcd certs: %w", err)
 	}
 
+	rotate, reason := needsEtcdCertificatesRotation(kmc, etcdCerts, time.Now())
+
 	for _, c := range etcdCerts {
-		if c.KeyPair == nil {
+		if c.KeyPair == nil || rotate {
 			req := csr.CertificateRequest{
 				KeyRequest: csr.NewKeyRequest(),
 				CN:         string(c.Purpose),
@@ -120,7 +130,77 @@ func (scope *kmcScope) ensureEtcdCertificates(ctx context.Context, kmc *km.Clust
 		owner = *kutil.GetExternalControllerRef(scope.externalOwner)
 	}
 
-	return etcdCerts.SaveGenerated(ctx, scope.client, util.ObjectKey(kmc), owner)
+	if !rotate {
+		return etcdCerts.SaveGenerated(ctx, scope.client, util.ObjectKey(kmc), owner)
+	}
+
+	logger.Info("Rotating etcd certificates", "reason", reason)
+	for _, c := range etcdCerts {
+		s := c.AsSecret(util.ObjectKey(kmc), owner)
+		s.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+		if err := scope.reconcileResource(ctx, kmc, s); err != nil {
+			return fmt.Errorf("error saving rotated %s certificate: %w", c.Purpose, err)
+		}
+	}
+
+	// The etcd members are restarted first. The control plane pods follow once all etcd members run with the new certificates.
+	kmc.Status.CertificateRotation = &km.CertificateRotationStatus{
+		Phase:            km.CertificateRotationPhaseRestartingEtcd,
+		LastRotationTime: metav1.Now(),
+	}
+	delete(kmc.Annotations, km.RotateCertificatesAnnotation)
+
+	return nil
+}
+
+// needsEtcdCertificatesRotation returns true and the reason if the etcd certificates must be rotated, either because it
+// was requested with the RotateCertificatesAnnotation or because one of the existing certificates expires soon.
+func needsEtcdCertificatesRotation(kmc *km.Cluster, etcdCerts secret.Certificates, now time.Time) (bool, string) {
+	if _, ok := kmc.Annotations[km.RotateCertificatesAnnotation]; ok {
+		return true, fmt.Sprintf("requested by the %s annotation", km.RotateCertificatesAnnotation)
+	}
+
+	// Give the secret cache some time to catch up with a previous rotation, so that the same certificates are not rotated twice.
+	if rotation := kmc.Status.CertificateRotation; rotation != nil && now.Sub(rotation.LastRotationTime.Time) < time.Hour {
+		return false, ""
+	}
+
+	for _, c := range etcdCerts {
+		if c.KeyPair == nil {
+			continue
+		}
+		cert, err := helpers.ParseCertificatePEM(c.KeyPair.Cert)
+		if err != nil {
+			return true, fmt.Sprintf("the %s certificate cannot be parsed: %v", c.Purpose, err)
+		}
+		if now.Add(etcdCertificateRenewalWindow).After(cert.NotAfter) {
+			return true, fmt.Sprintf("the %s certificate expires at %s", c.Purpose, cert.NotAfter.Format(time.RFC3339))
+		}
+	}
+
+	return false, ""
+}
+
+// certificatesRotatedAtAnnotations returns the pod template annotations restarting the pods after a certificate rotation.
+func certificatesRotatedAtAnnotations(rotatedAt *metav1.Time) map[string]string {
+	if rotatedAt == nil {
+		return nil
+	}
+	return map[string]string{km.CertificatesRotatedAtAnnotation: rotatedAt.UTC().Format(time.RFC3339)}
+}
+
+// isStatefulSetRolledOut returns true if all the replicas of the StatefulSet run the pod template restarted at the given time.
+func isStatefulSetRolledOut(sts *apps.StatefulSet, rotatedAt *metav1.Time) bool {
+	if sts == nil || sts.Spec.Replicas == nil || rotatedAt == nil {
+		return false
+	}
+	if sts.Spec.Template.Annotations[km.CertificatesRotatedAtAnnotation] != certificatesRotatedAtAnnotations(rotatedAt)[km.CertificatesRotatedAtAnnotation] {
+		return false
+	}
+	return sts.Status.ObservedGeneration >= sts.Generation &&
+		sts.Status.CurrentRevision == sts.Status.UpdateRevision &&
+		sts.Status.UpdatedReplicas == *sts.Spec.Replicas &&
+		sts.Status.ReadyReplicas == *sts.Spec.Replicas
 }
 
 func (scope *kmcScope) ensureHAProxyCerts(ctx context.Context, kmc *km.Cluster) error {
@@ -202,3 +282,25 @@ func (scope *kmcScope) ensureHAProxyCerts(ctx context.Context, kmc *km.Cluster)
 
 	return clusterCerts.SaveGenerated(ctx, scope.client, util.ObjectKey(kmc), *metav1.NewControllerRef(kmc, km.GroupVersion.WithKind("Cluster")))
 }
+
+// updateCertificatesStatus reports the expiry dates of the certificates generated for the cluster.
+func (scope *kmcScope) updateCertificatesStatus(ctx context.Context, kmc *km.Cluster) error {
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	if kmc.Spec.Storage.Type == km.StorageTypeEtcd {
+		certificates = append(certificates,
+			&secret.Certificate{Purpose: "apiserver-etcd-client"},
+			&secret.Certificate{Purpose: "etcd-server"},
+			&secret.Certificate{Purpose: "etcd-peer"},
+		)
+	}
+	if kmc.Spec.GetIngressEndpoint() != nil {
+		certificates = append(certificates, &secret.Certificate{Purpose: "ingress-haproxy"})
+	}
+
+	if err := certificates.LookupCached(ctx, scope.secretCachingClient, scope.client, util.ObjectKey(kmc)); err != nil {
+		return fmt.Errorf("error looking up cluster certs: %w", err)
+	}
+
+	kmc.Status.Certificates = kutil.CertificateStatuses(kmc.Name, certificates)
+	return nil
+}
@@ -0,0 +1,121 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/test/certificates"
+)
+
+func TestNeedsEtcdCertificatesRotation(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		kmc        *km.Cluster
+		notAfter   time.Time
+		wantRotate bool
+	}{
+		{
+			name:     "valid certificates",
+			kmc:      &km.Cluster{},
+			notAfter: now.Add(300 * 24 * time.Hour),
+		},
+		{
+			name: "requested by annotation",
+			kmc: &km.Cluster{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{km.RotateCertificatesAnnotation: ""},
+			}},
+			notAfter:   now.Add(300 * 24 * time.Hour),
+			wantRotate: true,
+		},
+		{
+			name:       "certificate expires soon",
+			kmc:        &km.Cluster{},
+			notAfter:   now.Add(7 * 24 * time.Hour),
+			wantRotate: true,
+		},
+		{
+			name: "certificate expires soon but was just rotated",
+			kmc: &km.Cluster{Status: km.ClusterStatus{CertificateRotation: &km.CertificateRotationStatus{
+				LastRotationTime: metav1.NewTime(now.Add(-time.Minute)),
+			}}},
+			notAfter: now.Add(7 * 24 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			etcdCerts := secret.Certificates{
+				&secret.Certificate{Purpose: "etcd-server", KeyPair: certificates.NewKeyPair(t, tt.notAfter)},
+				// Certificates not generated yet are ignored.
+				&secret.Certificate{Purpose: "etcd-peer"},
+			}
+			rotate, reason := needsEtcdCertificatesRotation(tt.kmc, etcdCerts, now)
+			assert.Equal(t, tt.wantRotate, rotate)
+			if tt.wantRotate {
+				assert.NotEmpty(t, reason)
+			}
+		})
+	}
+}
+
+func TestIsStatefulSetRolledOut(t *testing.T) {
+	rotatedAt := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	rolledOutSts := func() *apps.StatefulSet {
+		sts := &apps.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       apps.StatefulSetSpec{Replicas: new(int32(3))},
+			Status: apps.StatefulSetStatus{
+				ObservedGeneration: 2,
+				CurrentRevision:    "rev-2",
+				UpdateRevision:     "rev-2",
+				UpdatedReplicas:    3,
+				ReadyReplicas:      3,
+			},
+		}
+		sts.Spec.Template.Annotations = certificatesRotatedAtAnnotations(&rotatedAt)
+		return sts
+	}
+
+	assert.True(t, isStatefulSetRolledOut(rolledOutSts(), &rotatedAt))
+
+	sts := rolledOutSts()
+	sts.Spec.Template.Annotations = nil
+	assert.False(t, isStatefulSetRolledOut(sts, &rotatedAt), "template not updated yet")
+
+	sts = rolledOutSts()
+	sts.Status.CurrentRevision = "rev-1"
+	assert.False(t, isStatefulSetRolledOut(sts, &rotatedAt), "rollout in progress")
+
+	sts = rolledOutSts()
+	sts.Status.ReadyReplicas = 2
+	assert.False(t, isStatefulSetRolledOut(sts, &rotatedAt), "replica not ready")
+
+	assert.False(t, isStatefulSetRolledOut(rolledOutSts(), nil))
+}
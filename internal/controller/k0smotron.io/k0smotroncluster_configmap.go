@@ -19,7 +19,9 @@ package k0smotronio
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net"
+	"slices"
 	"sort"
 	"strconv"
 
@@ -78,7 +80,7 @@ func (scope *kmcScope) generateConfig(kmc *km.Cluster, sans []string) (v1.Config
 		}
 	}
 
-	if !nllbEnabled && kmc.Spec.Ingress == nil {
+	if !nllbEnabled && kmc.Spec.GetIngressEndpoint() == nil {
 		err := unstructured.SetNestedField(k0smotronValues, kmc.Spec.ExternalAddress, "spec", "api", "externalAddress")
 		if err != nil {
 			return v1.ConfigMap{}, nil, fmt.Errorf("error setting externalAddress: %v", err)
@@ -197,26 +199,35 @@ func genSANs(kmc *km.Cluster, c client.Client) ([]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get service %s: %w", svcName, err)
 	}
+	// A dual-stack service has a ClusterIP and a load balancer address for each of its IP families.
 	sans = append(sans, kmcService.Spec.ClusterIPs...)
+	for _, ingress := range kmcService.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			sans = append(sans, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			sans = append(sans, ingress.Hostname)
+		}
+	}
 
 	sans = append(sans, fmt.Sprintf("%s.svc.cluster.local", svcNamespacedName))
 
-	if kmc.Spec.Ingress != nil {
-		if kmc.Spec.Ingress.APIHost != "" {
+	if ingress := kmc.Spec.GetIngressEndpoint(); ingress != nil {
+		if ingress.APIHost != "" {
 			// Always add localhost to SANs if APIHost is set, as we create a local proxy to the API server
 			sans = append(sans, "127.0.0.1")
 			sans = append(sans, "localhost")
-			sans = append(sans, kmc.Spec.Ingress.APIHost)
+			sans = append(sans, ingress.APIHost)
 		}
-		if kmc.Spec.Ingress.KonnectivityHost != "" {
-			sans = append(sans, kmc.Spec.Ingress.KonnectivityHost)
+		if ingress.KonnectivityHost != "" {
+			sans = append(sans, ingress.KonnectivityHost)
 		}
 	}
 
 	// Sort the sans to ensure stable output order
 	sort.Strings(sans)
 
-	return sans, nil
+	return slices.Compact(sans), nil
 }
 
 func getV1Beta1Spec(kmc *km.Cluster, sans []string) map[string]any {
@@ -263,8 +274,8 @@ func getV1Beta1Spec(kmc *km.Cluster, sans []string) map[string]any {
 		}
 	}
 
-	if kmc.Spec.Ingress != nil {
-		v1beta1Spec["api"].(map[string]any)["externalAddress"] = net.JoinHostPort(kmc.Spec.Ingress.APIHost, strconv.FormatInt(kmc.Spec.Ingress.Port, 10))
+	if ingress := kmc.Spec.GetIngressEndpoint(); ingress != nil {
+		v1beta1Spec["api"].(map[string]any)["externalAddress"] = net.JoinHostPort(ingress.APIHost, strconv.FormatInt(ingress.Port, 10))
 		v1beta1Spec["api"].(map[string]any)["extraArgs"] = map[string]any{
 			"endpoint-reconciler-type": "none",
 		}
@@ -274,14 +285,42 @@ func getV1Beta1Spec(kmc *km.Cluster, sans []string) map[string]any {
 		if kmc.Spec.HasNativeIngressKonnectivity() {
 			// k0s deploys the konnectivity agent itself; point it at the ingress
 			// endpoint (host:ingressPort), reached via SNI routing.
-			konnectivity["externalAddress"] = net.JoinHostPort(kmc.Spec.Ingress.KonnectivityHost, strconv.FormatInt(kmc.Spec.Ingress.Port, 10))
+			konnectivity["externalAddress"] = net.JoinHostPort(ingress.KonnectivityHost, strconv.FormatInt(ingress.Port, 10))
 		} else {
 			// Older k0s ignores externalAddress; k0smotron ships its own agent
 			// manifest instead. Keep the host-only value for parity.
-			konnectivity["externalAddress"] = kmc.Spec.Ingress.KonnectivityHost
+			konnectivity["externalAddress"] = ingress.KonnectivityHost
 		}
 		v1beta1Spec["konnectivity"] = konnectivity
 	}
 
+	if kmc.Spec.Service.IsDualStack() {
+		// The IPv6 pod and service CIDRs default to the k0s ones unless set in the k0s config.
+		v1beta1Spec["network"] = map[string]any{
+			"dualStack": map[string]any{
+				"enabled": true,
+			},
+		}
+	}
+
+	if hasOIDCAuthentication(kmc) {
+		apiExtraArgs(v1beta1Spec)["authentication-config"] = authenticationConfigPath
+	}
+
+	if kmc.Spec.Audit != nil {
+		maps.Copy(apiExtraArgs(v1beta1Spec), auditAPIServerArgs(kmc.Spec.Audit))
+	}
+
 	return v1beta1Spec
 }
+
+// apiExtraArgs returns spec.api.extraArgs of the given k0s config spec, creating it if it does not exist yet.
+func apiExtraArgs(v1beta1Spec map[string]any) map[string]any {
+	api := v1beta1Spec["api"].(map[string]any)
+	extraArgs, ok := api["extraArgs"].(map[string]any)
+	if !ok {
+		extraArgs = map[string]any{}
+		api["extraArgs"] = extraArgs
+	}
+	return extraArgs
+}
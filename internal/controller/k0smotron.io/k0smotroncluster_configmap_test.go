@@ -25,6 +25,7 @@ import (
 	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -119,4 +120,74 @@ func TestGenerateCM(t *testing.T) {
 		assert.True(t, strings.Contains(conf, "my.external.address:443"), "The external address must be my.external.address")
 		assert.True(t, strings.Contains(conf, "my.konnectivity.external.address"), "The konnectivity  address must be my.konnectivity.external.address")
 	})
+
+	t.Run("config with dual-stack service", func(t *testing.T) {
+		kmc := km.Cluster{
+			Spec: km.ClusterSpec{
+				ExternalAddress: "2001:db8::1",
+				Service: km.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+				},
+				K0sConfig: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "k0s.k0sproject.io/v1beta1",
+					"kind":       "ClusterConfig",
+					"spec": map[string]any{
+						"network": map[string]any{
+							"provider": "calico",
+							"dualStack": map[string]any{
+								"IPv6podCIDR": "fd00::/108",
+							},
+						},
+					},
+				}},
+			},
+		}
+
+		_, k0sConfig, err := scope.generateConfig(&kmc, []string{})
+		require.NoError(t, err)
+
+		dualStack, _, err := unstructured.NestedMap(k0sConfig, "spec", "network", "dualStack")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"enabled": true, "IPv6podCIDR": "fd00::/108"}, dualStack)
+		provider, _, _ := unstructured.NestedString(k0sConfig, "spec", "network", "provider")
+		assert.Equal(t, "calico", provider)
+	})
+
+	t.Run("config with oidc authentication", func(t *testing.T) {
+		kmc := km.Cluster{
+			Spec: km.ClusterSpec{
+				Ingress: &km.IngressSpec{
+					APIHost:          "my.external.address",
+					KonnectivityHost: "my.konnectivity.external.address",
+					Port:             443,
+				},
+				Authentication: &km.AuthenticationSpec{
+					OIDC: &km.OIDCSpec{IssuerURL: "https://issuer.example.com", ClientID: "k0smotron"},
+				},
+				K0sConfig: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "k0s.k0sproject.io/v1beta1",
+					"kind":       "ClusterConfig",
+					"spec": map[string]any{
+						"api": map[string]any{
+							"extraArgs": map[string]any{
+								"anonymous-auth": "false",
+							},
+						},
+					},
+				}},
+			},
+		}
+
+		_, conf, err := scope.generateConfig(&kmc, []string{})
+		require.NoError(t, err)
+
+		extraArgs, found, err := unstructured.NestedStringMap(conf, "spec", "api", "extraArgs")
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, map[string]string{
+			"anonymous-auth":           "false",
+			"authentication-config":    "/etc/k0smotron/authentication/authentication-config.yaml",
+			"endpoint-reconciler-type": "none",
+		}, extraArgs)
+	})
 }
@@ -44,6 +44,7 @@ import (
 
 	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	kutil "github.com/k0sproject/k0smotron/v2/internal/controller/util"
+	"github.com/k0sproject/k0smotron/v2/internal/metrics"
 )
 
 var (
@@ -61,6 +62,9 @@ type ClusterReconciler struct {
 	ClientSet           *kubernetes.Clientset
 	RESTConfig          *rest.Config
 	Recorder            record.EventRecorder
+	// CertificateExpiryWarningWindow is the time before expiry after which certificates are reported as expiring soon.
+	// Defaults to 30 days.
+	CertificateExpiryWarningWindow time.Duration
 }
 
 const (
@@ -92,6 +96,8 @@ type currentReconcileState struct {
 	message      string
 	reason       string
 	controlplane controlplaneState
+	// etcdResuming is set while the etcd members of a resumed cluster are starting, the control plane waits for them.
+	etcdResuming bool
 }
 
 type controlplaneState struct {
@@ -136,9 +142,11 @@ func (scope *kmcScope) reconcileResource(ctx context.Context, kmc *km.Cluster, o
 // +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=list
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -166,6 +174,13 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
+	// The EtcdRestore controller owns the StatefulSets while a restore is running, reconciling them here
+	// would scale the control plane back up in the middle of the restore.
+	if restore, ok := kmc.Annotations[km.EtcdRestoreInProgressAnnotation]; ok && kmc.DeletionTimestamp.IsZero() {
+		logger.Info("Etcd restore in progress, skipping reconciliation", "etcdRestore", restore)
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
 	kmcScope, err := r.getKmcScope(ctx, kmc)
 	if err != nil {
 		logger.Error(err, "Error getting kmc scope")
@@ -219,6 +234,10 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return r.reconcileDelete(ctx, kmcScope, kmc)
 	}
 
+	if km.MigrateServiceAnnotations(kmc.Annotations, &kmc.Spec.Service) {
+		logger.Info("Migrated deprecated service annotations into spec.service")
+	}
+
 	if err := kmcScope.reconcileServices(ctx, kmc); err != nil {
 		kmc.SetReconciliationStatus(fmt.Sprintf("Failed reconciling services, %s", err.Error()))
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
@@ -234,6 +253,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
 	}
 
+	if err := kmcScope.reconcileAuthenticationConfig(ctx, kmc, r.Client); err != nil {
+		kmc.SetReconciliationStatus(fmt.Sprintf("Failed reconciling authentication config, %s", err.Error()))
+		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
+	}
+
 	if err := kmcScope.reconcileEntrypointCM(ctx, kmc); err != nil {
 		kmc.SetReconciliationStatus(fmt.Sprintf("Failed reconciling entrypoint configmap, %s", err.Error()))
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
@@ -246,6 +270,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}
 
+	if err := kmcScope.reconcileAutoscaling(ctx, kmc); err != nil {
+		kmc.SetReconciliationStatus(fmt.Sprintf("Failed reconciling autoscaling, %s", err.Error()))
+		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
+	}
+
 	if kmc.Spec.CertificateRefs == nil {
 		if err := kmcScope.ensureCertificates(ctx, kmc); err != nil {
 			return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
@@ -271,15 +300,22 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				Name: secret.Name(kmc.Name, secret.APIServerEtcdClient),
 			})
 		}
+	} else if _, ok := kmc.Annotations[km.RotateCertificatesAnnotation]; ok {
+		logger.Info("Ignoring certificate rotation request, only etcd certificates can be rotated", "storage", kmc.Spec.Storage.Type)
+		delete(kmc.Annotations, km.RotateCertificatesAnnotation)
 	}
 
-	if kmc.Spec.Ingress != nil {
+	if kmc.Spec.GetIngressEndpoint() != nil {
 		err := kmcScope.ensureHAProxyCerts(ctx, kmc)
 		if err != nil {
 			return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, fmt.Errorf("error generating ingress certificates: %w", err)
 		}
 	}
 
+	if err := kmcScope.updateCertificatesStatus(ctx, kmc); err != nil {
+		logger.Error(err, "Failed to report certificate expiry dates")
+	}
+
 	if err := kmcScope.reconcilePVC(ctx, kmc); err != nil {
 		kmc.SetReconciliationStatus(fmt.Sprintf("Failed reconciling PVCs: %s", err.Error()))
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, err
@@ -305,6 +341,16 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return result, err
 	}
 
+	// The kubeconfig is kept as is while the control plane is suspended, it is validated again once the control plane is resumed.
+	if kmc.Spec.Suspended {
+		kmc.SetReconciliationStatus("Control plane suspended")
+		return result, nil
+	}
+	if kmcScope.currentReconcileState.etcdResuming {
+		logger.Info("Waiting for etcd to resume before scaling up the control plane")
+		return result, nil
+	}
+
 	// We obtain the kubeconfig secret by running "k0s kubeconfig create admin" meaning that we need at least one ready replica ready.
 	if kmc.Status.ReadyReplicas > 0 {
 		if err := kmcScope.reconcileKubeConfigSecret(ctx, r.Client, kmc); err != nil {
@@ -320,6 +366,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	kmc.SetReconciliationStatus("Reconciliation successful")
 
+	// Keep track of the pods restarting after a certificate rotation, even if the StatefulSets are not watched.
+	if rotation := kmc.Status.CertificateRotation; rotation != nil && rotation.Phase != km.CertificateRotationPhaseCompleted && result.IsZero() {
+		result = ctrl.Result{RequeueAfter: 30 * time.Second}
+	}
+
 	return result, nil
 }
 
@@ -371,6 +422,8 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, scope *kmcScope
 		}
 	}
 
+	metrics.DeleteCertificateExpiry("Cluster", kmc.Namespace, kmc.Name)
+
 	scope.currentReconcileState.reason = km.ClusterDeletingDeletionCompletedReason
 
 	if updated := controllerutil.RemoveFinalizer(kmc, clusterFinalizer); updated {
@@ -382,7 +435,7 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, scope *kmcScope
 
 func (scope *kmcScope) ensureCertificates(ctx context.Context, kmc *km.Cluster) error {
 	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
-	if kmc.Spec.Ingress != nil {
+	if kmc.Spec.GetIngressEndpoint() != nil {
 		ingressCert := secret.Certificates{
 			&secret.Certificate{
 				Purpose:  "server",
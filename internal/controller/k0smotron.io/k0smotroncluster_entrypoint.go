@@ -100,7 +100,7 @@ func getControllerFlags(kmc *km.Cluster) string {
 		flags = append(flags, "--enable-dynamic-config")
 	}
 
-	if kmc.Spec.Ingress != nil {
+	if kmc.Spec.GetIngressEndpoint() != nil {
 		flags = append(flags, "--disable-components=endpoint-reconciler")
 	}
 
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -38,8 +39,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/cluster-api/util/secret"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// etcdSuspendedReplicasAnnotation stores the number of etcd members of a suspended cluster on the etcd StatefulSet.
+const etcdSuspendedReplicasAnnotation = "k0smotron.io/suspended-replicas"
+
 var etcdEntrypointScriptTmpl *template.Template
 
 func init() {
@@ -65,10 +70,28 @@ func (scope *kmcScope) reconcileEtcd(ctx context.Context, kmc *km.Cluster) (ctrl
 			return ctrl.Result{}, fmt.Errorf("error reconciling etcd defrag job: %w", err)
 		}
 	}
+	if kmc.Spec.Storage.Etcd.Backup.Enabled {
+		if err := scope.reconcileEtcdBackupJob(ctx, kmc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error reconciling etcd backup job: %w", err)
+		}
+	} else if err := scope.deleteEtcdBackupJob(ctx, kmc); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error deleting etcd backup job: %w", err)
+	}
 
 	return result, nil
 }
 
+// deleteEtcdBackupJob deletes the backup CronJob of a cluster whose backups were disabled, so it stops uploading
+// snapshots. Most clusters never enable backups, the CronJob is only deleted if it exists.
+func (scope *kmcScope) deleteEtcdBackupJob(ctx context.Context, kmc *km.Cluster) error {
+	cronJob := &batchv1.CronJob{}
+	err := scope.client.Get(ctx, client.ObjectKey{Name: kmc.GetEtcdBackupJobName(), Namespace: kmc.Namespace}, cronJob)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return client.IgnoreNotFound(scope.client.Delete(ctx, cronJob))
+}
+
 func (scope *kmcScope) reconcileEtcdSvc(ctx context.Context, kmc *km.Cluster) error {
 	// Service selector is immutable after creation. Add app.kubernetes.io/component only to metadata labels.
 	selectorLabels := kcontrollerutil.LabelsForEtcdK0smotronCluster(kmc)
@@ -160,44 +183,185 @@ func (scope *kmcScope) reconcileEtcdDefragJob(ctx context.Context, kmc *km.Clust
 									},
 								},
 							},
-							Volumes: []v1.Volume{{
-								Name: "certs",
-								VolumeSource: v1.VolumeSource{
-									Projected: &v1.ProjectedVolumeSource{
-										Sources: []v1.VolumeProjection{
-											{
-												Secret: &v1.SecretProjection{
-													LocalObjectReference: v1.LocalObjectReference{Name: secret.Name(kmc.Name, secret.EtcdCA)},
-													Items: []v1.KeyToPath{
-														{Key: "tls.crt", Path: "ca.crt"},
-														{Key: "tls.key", Path: "ca.key"},
-													},
-												},
-											}, {
-												Secret: &v1.SecretProjection{
-													LocalObjectReference: v1.LocalObjectReference{Name: secret.Name(kmc.Name, "etcd-server")},
-													Items: []v1.KeyToPath{
-														{Key: "tls.crt", Path: "client.crt"},
-														{Key: "tls.key", Path: "client.key"},
-													},
-												},
-											},
-										},
-									},
-								},
-							}},
+							Volumes: []v1.Volume{etcdClientCertsVolume(kmc)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_ = kcontrollerutil.SetExternalOwnerReference(kmc, &cronJob, scope.client.Scheme(), scope.externalOwner)
+
+	return scope.reconcileResource(ctx, kmc, &cronJob)
+}
+
+// etcdClientCertsVolume returns the projected volume with the etcd CA and the client certificate
+// used by the jobs talking to the etcd cluster.
+func etcdClientCertsVolume(kmc *km.Cluster) v1.Volume {
+	return v1.Volume{
+		Name: "certs",
+		VolumeSource: v1.VolumeSource{
+			Projected: &v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{
+						Secret: &v1.SecretProjection{
+							LocalObjectReference: v1.LocalObjectReference{Name: secret.Name(kmc.Name, secret.EtcdCA)},
+							Items: []v1.KeyToPath{
+								{Key: "tls.crt", Path: "ca.crt"},
+								{Key: "tls.key", Path: "ca.key"},
+							},
+						},
+					}, {
+						Secret: &v1.SecretProjection{
+							LocalObjectReference: v1.LocalObjectReference{Name: secret.Name(kmc.Name, "etcd-server")},
+							Items: []v1.KeyToPath{
+								{Key: "tls.crt", Path: "client.crt"},
+								{Key: "tls.key", Path: "client.key"},
+							},
 						},
 					},
 				},
 			},
 		},
 	}
+}
+
+func (scope *kmcScope) reconcileEtcdBackupJob(ctx context.Context, kmc *km.Cluster) error {
+	cronJob := generateEtcdBackupCronJob(kmc)
 
 	_ = kcontrollerutil.SetExternalOwnerReference(kmc, &cronJob, scope.client.Scheme(), scope.externalOwner)
 
 	return scope.reconcileResource(ctx, kmc, &cronJob)
 }
 
+func generateEtcdBackupCronJob(kmc *km.Cluster) batchv1.CronJob {
+	selectorLabels := kcontrollerutil.LabelsForEtcdK0smotronCluster(kmc)
+	metadataLabels := make(map[string]string, len(selectorLabels)+1)
+	maps.Copy(metadataLabels, selectorLabels)
+	metadataLabels[kcontrollerutil.ComponentLabel] = kcontrollerutil.ComponentEtcd
+
+	backup := kmc.Spec.Storage.Etcd.Backup
+	snapshotEnv := []v1.EnvVar{
+		{Name: "ETCDCTL_ENDPOINTS", Value: fmt.Sprintf("https://%s:2379", kmc.GetEtcdServiceName())},
+		{Name: "ETCDCTL_CACERT", Value: "/var/lib/k0s/pki/etcd/ca.crt"},
+		{Name: "ETCDCTL_CERT", Value: "/var/lib/k0s/pki/etcd/client.crt"},
+		{Name: "ETCDCTL_KEY", Value: "/var/lib/k0s/pki/etcd/client.key"},
+		{Name: "RETENTION", Value: fmt.Sprintf("%d", backup.Retention)},
+	}
+	snapshotContainer := v1.Container{
+		Name:            "etcd-snapshot",
+		Image:           kmc.Spec.Storage.Etcd.Image,
+		ImagePullPolicy: v1.PullIfNotPresent,
+		Command:         []string{"/bin/bash"},
+		Env:             snapshotEnv,
+		VolumeMounts: []v1.VolumeMount{
+			{Name: "certs", MountPath: "/var/lib/k0s/pki/etcd/"},
+			{Name: "snapshots", MountPath: "/snapshots"},
+		},
+	}
+
+	podSpec := v1.PodSpec{
+		RestartPolicy:                v1.RestartPolicyOnFailure,
+		AutomountServiceAccountToken: new(false),
+		Volumes:                      []v1.Volume{etcdClientCertsVolume(kmc)},
+	}
+
+	if backup.Destination.PVC != nil {
+		// Snapshots are written directly to the claim, so a single container takes and prunes them.
+		snapshotContainer.Args = []string{"-c", etcdSnapshotScript + etcdSnapshotPruneScript}
+		podSpec.Containers = []v1.Container{snapshotContainer}
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name: "snapshots",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: backup.Destination.PVC.ClaimName},
+			},
+		})
+	} else if backup.Destination.S3 != nil {
+		// The etcd image has no S3 client, so the snapshot is taken into an emptyDir by an init container
+		// and uploaded by the aws CLI container.
+		snapshotContainer.Args = []string{"-c", etcdSnapshotScript}
+		podSpec.InitContainers = []v1.Container{snapshotContainer}
+		uploadContainer := s3Container(backup.Destination.S3, "etcd-snapshot-upload", etcdSnapshotUploadScript)
+		uploadContainer.Env = append(uploadContainer.Env, v1.EnvVar{Name: "RETENTION", Value: fmt.Sprintf("%d", backup.Retention)})
+		podSpec.Containers = []v1.Container{uploadContainer}
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name:         "snapshots",
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		})
+	}
+
+	if kmc.Spec.ServiceAccount != "" {
+		podSpec.ServiceAccountName = kmc.Spec.ServiceAccount
+	}
+
+	return batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kmc.GetEtcdBackupJobName(),
+			Namespace:   kmc.Namespace,
+			Labels:      metadataLabels,
+			Annotations: kcontrollerutil.AnnotationsForK0smotronCluster(kmc),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          backup.Schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: v1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: metadataLabels,
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}
+}
+
+// s3Container returns a container running the given script with the aws CLI configured for the S3 location.
+// The location URL is exposed as S3_URL.
+func s3Container(loc *km.EtcdSnapshotS3Location, name string, script string) v1.Container {
+	env := []v1.EnvVar{
+		{Name: "S3_URL", Value: s3URL(loc)},
+	}
+	if loc.Endpoint != "" {
+		env = append(env, v1.EnvVar{Name: "AWS_ENDPOINT_URL", Value: loc.Endpoint})
+	}
+	if loc.Region != "" {
+		env = append(env, v1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: loc.Region})
+	}
+
+	return v1.Container{
+		Name:            name,
+		Image:           loc.Image,
+		ImagePullPolicy: v1.PullIfNotPresent,
+		Command:         []string{"/bin/bash"},
+		Args:            []string{"-c", script},
+		Env:             env,
+		EnvFrom: []v1.EnvFromSource{{
+			SecretRef: &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: loc.CredentialsSecretName},
+			},
+		}},
+		VolumeMounts: []v1.VolumeMount{
+			{Name: "snapshots", MountPath: "/snapshots"},
+		},
+	}
+}
+
+func s3URL(loc *km.EtcdSnapshotS3Location) string {
+	prefix := strings.Trim(loc.Prefix, "/")
+	if prefix == "" {
+		return fmt.Sprintf("s3://%s", loc.Bucket)
+	}
+	return fmt.Sprintf("s3://%s/%s", loc.Bucket, prefix)
+}
+
 func (scope *kmcScope) reconcileEtcdStatefulSet(ctx context.Context, kmc *km.Cluster) (ctrl.Result, error) {
 	foundStatefulSet, err := scope.clienSet.AppsV1().StatefulSets(kmc.Namespace).Get(ctx, kmc.GetEtcdStatefulSetName(), metav1.GetOptions{})
 	if err != nil {
@@ -206,8 +370,34 @@ func (scope *kmcScope) reconcileEtcdStatefulSet(ctx context.Context, kmc *km.Clu
 		}
 	}
 	desiredReplicas := calculateDesiredReplicas(kmc, foundStatefulSet)
+	suspendedReplicas, resuming := foundStatefulSet.Annotations[etcdSuspendedReplicasAnnotation]
+	switch {
+	case kmc.Spec.Suspended:
+		// Keep the size of the etcd cluster, all the members must be started again on resume.
+		if !resuming {
+			suspendedReplicas = strconv.Itoa(int(desiredReplicas))
+		}
+		desiredReplicas = 0
+	case resuming:
+		// All members are started at once: a member cannot become ready before it has quorum, so the one-member-at-a-time
+		// scale up below would never progress. The annotation is kept until all the members are ready, holding back the
+		// control plane replicas.
+		if n, err := strconv.ParseInt(suspendedReplicas, 10, 32); err == nil && int32(n) > desiredReplicas {
+			desiredReplicas = int32(n)
+		}
+		if foundStatefulSet.Status.ReadyReplicas < desiredReplicas {
+			scope.currentReconcileState.etcdResuming = true
+		} else {
+			suspendedReplicas = ""
+		}
 	// We can't check for nil due to the client_go implementation
-	if foundStatefulSet.GetName() != "" {
+	case foundStatefulSet.GetName() != "":
+		// Once all etcd members run with the rotated certificates, the control plane pods can be restarted.
+		rotation := kmc.Status.CertificateRotation
+		if rotation != nil && rotation.Phase == km.CertificateRotationPhaseRestartingEtcd && isStatefulSetRolledOut(foundStatefulSet, &rotation.LastRotationTime) {
+			rotation.Phase = km.CertificateRotationPhaseRestartingControlPlane
+			rotation.ControlPlaneRestartTime = new(metav1.Now())
+		}
 
 		// If we want to scale up existing etcd statefulset, we always scale up by 1 replica at a time and wait for the previous member to be ready
 		// This is to avoid the situation where the new member is not able to join the cluster because the previous member is not ready
@@ -223,10 +413,24 @@ func (scope *kmcScope) reconcileEtcdStatefulSet(ctx context.Context, kmc *km.Clu
 	}
 
 	statefulSet := generateEtcdStatefulSet(kmc, foundStatefulSet, desiredReplicas)
+	if suspendedReplicas != "" {
+		statefulSet.Annotations = maps.Clone(statefulSet.Annotations)
+		if statefulSet.Annotations == nil {
+			statefulSet.Annotations = make(map[string]string)
+		}
+		statefulSet.Annotations[etcdSuspendedReplicasAnnotation] = suspendedReplicas
+	}
 
 	_ = kcontrollerutil.SetExternalOwnerReference(kmc, &statefulSet, scope.client.Scheme(), scope.externalOwner)
 
-	return ctrl.Result{}, scope.reconcileResource(ctx, kmc, &statefulSet)
+	if err := scope.reconcileResource(ctx, kmc, &statefulSet); err != nil {
+		return ctrl.Result{}, err
+	}
+	if scope.currentReconcileState.etcdResuming {
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+
+	return ctrl.Result{}, nil
 }
 
 func generateEtcdStatefulSet(kmc *km.Cluster, existingSts *apps.StatefulSet, replicas int32) apps.StatefulSet {
@@ -285,7 +489,8 @@ func generateEtcdStatefulSet(kmc *km.Cluster, existingSts *apps.StatefulSet, rep
 			PodManagementPolicy: apps.ParallelPodManagement,
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: etcdPodAnnotations(kmc),
 				},
 				Spec: v1.PodSpec{
 					AutomountServiceAccountToken: new(false),
@@ -411,6 +616,14 @@ func generateEtcdStatefulSet(kmc *km.Cluster, existingSts *apps.StatefulSet, rep
 	return statefulSet
 }
 
+// etcdPodAnnotations returns the annotations of the etcd pods.
+func etcdPodAnnotations(kmc *km.Cluster) map[string]string {
+	if kmc.Status.CertificateRotation == nil {
+		return nil
+	}
+	return certificatesRotatedAtAnnotations(&kmc.Status.CertificateRotation.LastRotationTime)
+}
+
 func initialCluster(kmc *km.Cluster, replicas int32) string {
 	var members []string
 	stsName := kmc.GetEtcdStatefulSetName()
@@ -514,6 +727,36 @@ etcd --name ${HOSTNAME} \
   --data-dir=/var/lib/k0s/etcd
 `
 
+// Snapshot names sort chronologically, which the prune scripts rely on.
+const etcdSnapshotScript = `
+set -eu
+
+SNAPSHOT="snapshot-$(date -u +%Y%m%d%H%M%S).db"
+etcdctl snapshot save "/snapshots/${SNAPSHOT}.part"
+mv "/snapshots/${SNAPSHOT}.part" "/snapshots/${SNAPSHOT}"
+echo "Saved snapshot ${SNAPSHOT}"
+`
+
+const etcdSnapshotPruneScript = `
+ls -1 /snapshots | grep -E '^snapshot-[0-9]+\.db$' | sort -r | tail -n +$((RETENTION + 1)) | while read -r old; do
+  echo "Removing snapshot ${old}"
+  rm -f "/snapshots/${old}"
+done
+`
+
+const etcdSnapshotUploadScript = `
+set -eu
+
+for f in /snapshots/snapshot-*.db; do
+  aws s3 cp "${f}" "${S3_URL}/$(basename "${f}")"
+done
+
+aws s3 ls "${S3_URL}/" | awk '{ print $4 }' | grep -E '^snapshot-[0-9]+\.db$' | sort -r | tail -n +$((RETENTION + 1)) | while read -r old; do
+  echo "Removing snapshot ${old}"
+  aws s3 rm "${S3_URL}/${old}"
+done
+`
+
 var initEntryScript = `
 #!/bin/bash
 
@@ -19,14 +19,23 @@ limitations under the License.
 package k0smotronio
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestEtcd_calculateDesiredReplicas(t *testing.T) {
@@ -163,3 +172,167 @@ func TestEtcd_generateEtcdStatefulSet(t *testing.T) {
 		})
 	}
 }
+
+func TestEtcd_generateEtcdStatefulSet_CertificateRotation(t *testing.T) {
+	kmc := &km.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	sts := generateEtcdStatefulSet(kmc, nil, 1)
+	assert.NotContains(t, sts.Spec.Template.Annotations, km.CertificatesRotatedAtAnnotation)
+
+	kmc.Status.CertificateRotation = &km.CertificateRotationStatus{
+		Phase:            km.CertificateRotationPhaseRestartingEtcd,
+		LastRotationTime: metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	sts = generateEtcdStatefulSet(kmc, nil, 1)
+	assert.Equal(t, "2026-01-01T00:00:00Z", sts.Spec.Template.Annotations[km.CertificatesRotatedAtAnnotation])
+}
+
+func TestEtcd_generateEtcdBackupCronJob(t *testing.T) {
+	t.Run("pvc destination", func(t *testing.T) {
+		kmc := &km.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: km.ClusterSpec{Storage: km.StorageSpec{Type: km.StorageTypeEtcd, Etcd: km.EtcdSpec{
+				Image: "etcd:test",
+				Backup: km.EtcdBackupSpec{
+					Enabled:     true,
+					Schedule:    "0 */6 * * *",
+					Retention:   3,
+					Destination: km.EtcdSnapshotLocation{PVC: &km.EtcdSnapshotPVCLocation{ClaimName: "snapshots"}},
+				},
+			}}},
+		}
+
+		cj := generateEtcdBackupCronJob(kmc)
+		require.Equal(t, "kmc-test-etcd-backup", cj.Name)
+		require.Equal(t, "0 */6 * * *", cj.Spec.Schedule)
+
+		podSpec := cj.Spec.JobTemplate.Spec.Template.Spec
+		require.Empty(t, podSpec.InitContainers)
+		require.Len(t, podSpec.Containers, 1)
+		assert.Equal(t, "etcd:test", podSpec.Containers[0].Image)
+		assert.Contains(t, podSpec.Containers[0].Args[1], "etcdctl snapshot save")
+		assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "RETENTION", Value: "3"})
+
+		require.Len(t, podSpec.Volumes, 2)
+		assert.Equal(t, etcdClientCertsVolume(kmc), podSpec.Volumes[0])
+		assert.Equal(t, "snapshots", podSpec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	})
+
+	t.Run("s3 destination", func(t *testing.T) {
+		kmc := &km.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: km.ClusterSpec{Storage: km.StorageSpec{Type: km.StorageTypeEtcd, Etcd: km.EtcdSpec{
+				Image: "etcd:test",
+				Backup: km.EtcdBackupSpec{
+					Enabled:   true,
+					Retention: 7,
+					Destination: km.EtcdSnapshotLocation{S3: &km.EtcdSnapshotS3Location{
+						Bucket:                "backups",
+						Prefix:                "clusters/test",
+						Endpoint:              "https://minio.example.com",
+						CredentialsSecretName: "s3-creds",
+						Image:                 "aws-cli:test",
+					}},
+				},
+			}}},
+		}
+
+		podSpec := generateEtcdBackupCronJob(kmc).Spec.JobTemplate.Spec.Template.Spec
+		require.Len(t, podSpec.InitContainers, 1)
+		assert.Contains(t, podSpec.InitContainers[0].Args[1], "etcdctl snapshot save")
+
+		require.Len(t, podSpec.Containers, 1)
+		upload := podSpec.Containers[0]
+		assert.Equal(t, "aws-cli:test", upload.Image)
+		assert.Contains(t, upload.Env, corev1.EnvVar{Name: "S3_URL", Value: "s3://backups/clusters/test"})
+		assert.Contains(t, upload.Env, corev1.EnvVar{Name: "AWS_ENDPOINT_URL", Value: "https://minio.example.com"})
+		assert.Contains(t, upload.Env, corev1.EnvVar{Name: "RETENTION", Value: "7"})
+		require.Len(t, upload.EnvFrom, 1)
+		assert.Equal(t, "s3-creds", upload.EnvFrom[0].SecretRef.Name)
+
+		require.Len(t, podSpec.Volumes, 2)
+		assert.NotNil(t, podSpec.Volumes[1].EmptyDir)
+	})
+}
+
+func TestEtcd_deleteEtcdBackupJob(t *testing.T) {
+	ctx := context.Background()
+	kmc := &km.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: kmc.GetEtcdBackupJobName(), Namespace: kmc.Namespace}}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cronJob).Build()
+	scope := &kmcScope{client: c}
+
+	// The CronJob of a cluster whose backups were disabled is deleted, a missing one is ignored.
+	require.NoError(t, scope.deleteEtcdBackupJob(ctx, kmc))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(cronJob), cronJob)))
+	require.NoError(t, scope.deleteEtcdBackupJob(ctx, kmc))
+}
+
+func TestEtcd_generateEtcdRestoreJob(t *testing.T) {
+	kmc := &km.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       km.ClusterSpec{Storage: km.StorageSpec{Type: km.StorageTypeEtcd, Etcd: km.EtcdSpec{Image: "etcd:test"}}},
+	}
+	restore := &km.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec: km.EtcdRestoreSpec{
+			ClusterName: "test",
+			Source:      km.EtcdSnapshotLocation{PVC: &km.EtcdSnapshotPVCLocation{ClaimName: "snapshots"}},
+			Snapshot:    "snapshot-20260101000000.db",
+		},
+		Status: km.EtcdRestoreStatus{EtcdReplicas: 3},
+	}
+
+	job := generateEtcdRestoreJob(kmc, restore, 1)
+	require.Equal(t, "kmc-test-etcd-restore-1", job.Name)
+
+	podSpec := job.Spec.Template.Spec
+	require.Len(t, podSpec.Containers, 1)
+	env := podSpec.Containers[0].Env
+	assert.Contains(t, env, corev1.EnvVar{Name: "MEMBER_NAME", Value: "kmc-test-etcd-1"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "SNAPSHOT", Value: "snapshot-20260101000000.db"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "ETCD_INITIAL_CLUSTER", Value: initialCluster(kmc, 3)})
+	assert.NotEqual(t, "etcd", job.Spec.Template.Labels["component"])
+
+	require.Len(t, podSpec.Volumes, 2)
+	assert.Equal(t, "etcd-data-kmc-test-etcd-1", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "snapshots", podSpec.Volumes[1].PersistentVolumeClaim.ClaimName)
+}
+
+func TestEtcdRestore_restoreMembersFromPVC(t *testing.T) {
+	ctx := context.Background()
+	kmc := &km.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       km.ClusterSpec{Storage: km.StorageSpec{Type: km.StorageTypeEtcd, Etcd: km.EtcdSpec{Image: "etcd:test"}}},
+	}
+	restore := &km.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec: km.EtcdRestoreSpec{
+			ClusterName: "test",
+			Source:      km.EtcdSnapshotLocation{PVC: &km.EtcdSnapshotPVCLocation{ClaimName: "snapshots"}},
+			Snapshot:    "snapshot-20260101000000.db",
+		},
+		Status: km.EtcdRestoreStatus{EtcdReplicas: 2},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	r := &EtcdRestoreReconciler{}
+
+	// The snapshot PVC is mounted by one restore job at a time.
+	_, err := r.restoreMembers(ctx, c, restore, kmc)
+	require.NoError(t, err)
+	_, err = r.restoreMembers(ctx, c, restore, kmc)
+	require.NoError(t, err)
+	jobs := &batchv1.JobList{}
+	require.NoError(t, c.List(ctx, jobs))
+	require.Len(t, jobs.Items, 1)
+	assert.Equal(t, "kmc-test-etcd-restore-0", jobs.Items[0].Name)
+
+	job := jobs.Items[0]
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	require.NoError(t, c.Status().Update(ctx, &job))
+	_, err = r.restoreMembers(ctx, c, restore, kmc)
+	require.NoError(t, err)
+	require.NoError(t, c.List(ctx, jobs))
+	assert.Len(t, jobs.Items, 2)
+	assert.Equal(t, km.EtcdRestorePhase(""), restore.Status.Phase)
+}
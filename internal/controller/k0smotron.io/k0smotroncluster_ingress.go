@@ -30,8 +30,12 @@ import (
 	kcontrollerutil "github.com/k0sproject/k0smotron/v2/internal/controller/util"
 )
 
+// tlsRouteAPIVersion is the Gateway API version of the TLSRoutes.
+const tlsRouteAPIVersion = "gateway.networking.k8s.io/v1alpha2"
+
 func (scope *kmcScope) reconcileIngress(ctx context.Context, kmc *km.Cluster) error {
-	if kmc.Spec.Ingress == nil {
+	ingress := kmc.Spec.GetIngressEndpoint()
+	if ingress == nil {
 		return nil
 	}
 
@@ -111,7 +115,17 @@ func (scope *kmcScope) reconcileIngress(ctx context.Context, kmc *km.Cluster) er
 		kmc.Spec.Manifests = append(kmc.Spec.Manifests, konnectivityVolume)
 	}
 
-	if *kmc.Spec.Ingress.Deploy {
+	if kmc.Spec.Gateway != nil {
+		for _, route := range scope.generateTLSRoutes(kmc) {
+			_ = kcontrollerutil.SetExternalOwnerReference(kmc, route, scope.client.Scheme(), scope.externalOwner)
+			if err := scope.reconcileResource(ctx, kmc, route); err != nil {
+				return fmt.Errorf("failed to reconcile tlsroute %s: %w", route.GetName(), err)
+			}
+		}
+		return nil
+	}
+
+	if *ingress.Deploy {
 		ingress := scope.generateIngress(kmc)
 		_ = kcontrollerutil.SetExternalOwnerReference(kmc, &ingress, scope.client.Scheme(), scope.externalOwner)
 		return scope.reconcileResource(ctx, kmc, &ingress)
@@ -190,6 +204,7 @@ func (scope *kmcScope) getKonnectivityAgentPullPolicy(kmc *km.Cluster) string {
 }
 
 func (scope *kmcScope) generateIngressManifestsConfigMap(kmc *km.Cluster) (corev1.ConfigMap, error) {
+	ingress := kmc.Spec.GetIngressEndpoint()
 	configMap := corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ConfigMap",
@@ -231,7 +246,7 @@ data:
     backend kubeapi_back
         mode tcp
         server kube_api %s:%d ssl verify required ca-file /etc/haproxy/certs/ca.crt sni str(%s)
-`, kmc.Spec.Ingress.APIHost, kmc.Spec.Ingress.Port, kmc.Spec.Ingress.APIHost),
+`, ingress.APIHost, ingress.Port, ingress.APIHost),
 			"2_haproxy-ds.yaml": `apiVersion: apps/v1
 kind: DaemonSet
 metadata:
@@ -316,6 +331,7 @@ spec:
 }
 
 func (scope *kmcScope) generateKonnectivityIngressConfigMap(kmc *km.Cluster) (corev1.ConfigMap, error) {
+	ingress := kmc.Spec.GetIngressEndpoint()
 	configMap := corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ConfigMap",
@@ -418,7 +434,7 @@ spec:
             sources:
               - serviceAccountToken:
                   path: konnectivity-agent-token
-                  audience: system:konnectivity-server`, scope.getKonnectivityAgentImage(kmc), scope.getKonnectivityAgentPullPolicy(kmc), kmc.Spec.Ingress.KonnectivityHost, kmc.Spec.Ingress.Port),
+                  audience: system:konnectivity-server`, scope.getKonnectivityAgentImage(kmc), scope.getKonnectivityAgentPullPolicy(kmc), ingress.KonnectivityHost, ingress.Port),
 		},
 	}
 
@@ -489,3 +505,52 @@ func (scope *kmcScope) generateIngress(kmc *km.Cluster) v1.Ingress {
 
 	return ingress
 }
+
+// generateTLSRoutes generates the TLSRoutes passing the TLS connections to the Kubernetes API and konnectivity server
+// hosts through to the cluster service.
+func (scope *kmcScope) generateTLSRoutes(kmc *km.Cluster) []*unstructured.Unstructured {
+	annotations := kcontrollerutil.AnnotationsForK0smotronCluster(kmc)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	maps.Copy(annotations, kmc.Spec.Gateway.Annotations)
+
+	parentRefs := make([]any, 0, len(kmc.Spec.Gateway.ParentRefs))
+	for _, ref := range kmc.Spec.Gateway.ParentRefs {
+		parentRef := map[string]any{"name": ref.Name}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	route := func(name, host string, port int) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{
+				"parentRefs": parentRefs,
+				"hostnames":  []any{host},
+				"rules": []any{map[string]any{
+					"backendRefs": []any{map[string]any{
+						"name": kmc.GetServiceName(),
+						"port": int64(port),
+					}},
+				}},
+			},
+		}}
+		u.SetAPIVersion(tlsRouteAPIVersion)
+		u.SetKind("TLSRoute")
+		u.SetName(name)
+		u.SetNamespace(kmc.Namespace)
+		u.SetAnnotations(maps.Clone(annotations))
+		u.SetLabels(kcontrollerutil.LabelsForK0smotronComponent(kmc, kcontrollerutil.ComponentIngress))
+		return u
+	}
+
+	return []*unstructured.Unstructured{
+		route(kmc.GetAPITLSRouteName(), kmc.Spec.Gateway.APIHost, kmc.Spec.Service.APIPort),
+		route(kmc.GetKonnectivityTLSRouteName(), kmc.Spec.Gateway.KonnectivityHost, kmc.Spec.Service.KonnectivityPort),
+	}
+}
@@ -22,6 +22,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
@@ -235,3 +238,47 @@ func TestHasNativeIngressKonnectivity(t *testing.T) {
 		assert.Equal(t, tc.native, spec.HasNativeIngressKonnectivity(), "version: %q", tc.version)
 	}
 }
+
+func TestGenerateTLSRoutes(t *testing.T) {
+	scope := &kmcScope{}
+	kmc := &km.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: km.ClusterSpec{
+			Service: km.ServiceSpec{Type: corev1.ServiceTypeClusterIP, APIPort: 30443, KonnectivityPort: 30132},
+			Gateway: &km.GatewaySpec{
+				ParentRefs:       []km.GatewayParentReference{{Name: "gateway", Namespace: "gateway-system", SectionName: "tls"}},
+				Port:             443,
+				APIHost:          "api.example.com",
+				KonnectivityHost: "konnectivity.example.com",
+				Annotations:      map[string]string{"foo": "bar"},
+			},
+		},
+	}
+
+	routes := scope.generateTLSRoutes(kmc)
+	require.Len(t, routes, 2)
+	for i, want := range []struct {
+		name string
+		host string
+		port int64
+	}{
+		{"kmc-test-api", "api.example.com", 30443},
+		{"kmc-test-konnectivity", "konnectivity.example.com", 30132},
+	} {
+		route := routes[i]
+		assert.Equal(t, "TLSRoute", route.GetKind())
+		assert.Equal(t, want.name, route.GetName())
+		assert.Equal(t, "bar", route.GetAnnotations()["foo"])
+		hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		assert.Equal(t, []string{want.host}, hostnames)
+		parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+		assert.Equal(t, []any{map[string]any{"name": "gateway", "namespace": "gateway-system", "sectionName": "tls"}}, parentRefs)
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		assert.Equal(t, []any{map[string]any{"backendRefs": []any{map[string]any{"name": "kmc-test", "port": want.port}}}}, rules)
+	}
+
+	// The gateway mode shares the worker-side HAProxy setup with the ingress mode.
+	configMap, err := scope.generateIngressManifestsConfigMap(kmc)
+	require.NoError(t, err)
+	assert.Contains(t, configMap.Data["1_haproxy-configmap.yaml"], "server kube_api api.example.com:443")
+}
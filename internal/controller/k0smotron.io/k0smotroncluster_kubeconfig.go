@@ -137,8 +137,12 @@ func rewriteKubeconfigValues(kubeconfigYAML string, kmc *km.Cluster) (string, er
 	if srcCluster.Server == "" {
 		return "", fmt.Errorf("cluster server is empty")
 	}
-	if kmc.Spec.Ingress != nil {
-		srcCluster.Server = fmt.Sprintf("https://%s:%d", kmc.Spec.Ingress.APIHost, kmc.Spec.Ingress.Port)
+	srcCluster.Server, err = kcontrollerutil.NormalizeServerURL(srcCluster.Server)
+	if err != nil {
+		return "", err
+	}
+	if ingress := kmc.Spec.GetIngressEndpoint(); ingress != nil {
+		srcCluster.Server = kcontrollerutil.ServerURL(ingress.APIHost, ingress.Port)
 	}
 	if len(srcUser.ClientCertificateData) == 0 || len(srcUser.ClientKeyData) == 0 {
 		return "", fmt.Errorf("client certificate/key data not found in kubeconfig")
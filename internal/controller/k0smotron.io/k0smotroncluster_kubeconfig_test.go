@@ -18,6 +18,7 @@ limitations under the License.
 package k0smotronio
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
@@ -93,3 +94,24 @@ func TestRewriteKubeconfigNames(t *testing.T) {
 		t.Fatalf("kubeconfig mismatch:\nGot:\n%s\nWant:\n%s", string(gotBytes), string(wantBytes))
 	}
 }
+
+func TestRewriteKubeconfigIPv6Server(t *testing.T) {
+	for server, want := range map[string]string{
+		"https://fd00::1:30443":   "https://[fd00::1]:30443",
+		"https://[fd00::1]:30443": "https://[fd00::1]:30443",
+		"https://10.0.0.1:30443":  "https://10.0.0.1:30443",
+	} {
+		kubeconfig := strings.Replace(sampleKubeconfig, "https://k8s-default-xxx-xxx-xxx.elb.ap-northeast-1.amazonaws.com:6443", server, 1)
+		out, err := rewriteKubeconfigValues(kubeconfig, &v1beta2.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "wl1", Namespace: "default"}})
+		if err != nil {
+			t.Fatalf("rewriteKubeconfigValues returned error: %v", err)
+		}
+		cfg, err := clientcmd.Load([]byte(out))
+		if err != nil {
+			t.Fatalf("failed to load processed kubeconfig: %v", err)
+		}
+		if got := cfg.Clusters["wl1-k0s"].Server; got != want {
+			t.Errorf("server %q: got %q, want %q", server, got, want)
+		}
+	}
+}
@@ -24,7 +24,6 @@ import (
 
 	"github.com/k0sproject/k0smotron/v2/internal/controller/util"
 
-	deprecatedkmc "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta1"
 	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -90,10 +89,10 @@ func generateService(kmc *km.Cluster) v1.Service {
 
 	metadataLabels := map[string]string{}
 	maps.Copy(metadataLabels, util.LabelsForK0smotronComponent(kmc, util.ComponentControlPlane))
-	maps.Copy(metadataLabels, deprecatedkmc.GetServiceLabels(kmc.Annotations))
+	maps.Copy(metadataLabels, kmc.Spec.Service.Labels)
 	metadataAnnotations := map[string]string{}
 	maps.Copy(metadataAnnotations, util.AnnotationsForK0smotronCluster(kmc))
-	maps.Copy(metadataAnnotations, deprecatedkmc.GetServiceAnnotations(kmc.Annotations))
+	maps.Copy(metadataAnnotations, kmc.Spec.Service.Annotations)
 
 	svc := v1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -107,15 +106,22 @@ func generateService(kmc *km.Cluster) v1.Service {
 			Annotations: metadataAnnotations,
 		},
 		Spec: v1.ServiceSpec{
-			Type:                  kmc.Spec.Service.Type,
-			Selector:              util.LabelsForK0smotronCluster(kmc),
-			Ports:                 ports,
-			ExternalTrafficPolicy: deprecatedkmc.GetServiceExternalTrafficPolicy(kmc.Annotations),
+			Type:            kmc.Spec.Service.Type,
+			Selector:        util.LabelsForK0smotronCluster(kmc),
+			Ports:           ports,
+			IPFamilies:      kmc.Spec.Service.IPFamilies,
+			IPFamilyPolicy:  kmc.Spec.Service.IPFamilyPolicy,
+			SessionAffinity: kmc.Spec.Service.SessionAffinity,
 		},
 	}
 
+	if kmc.Spec.Service.Type != v1.ServiceTypeClusterIP {
+		svc.Spec.ExternalTrafficPolicy = kmc.Spec.Service.ExternalTrafficPolicy
+	}
 	if kmc.Spec.Service.Type == v1.ServiceTypeLoadBalancer {
-		svc.Spec.LoadBalancerClass = deprecatedkmc.GetServiceLoadBalancerClass(kmc.Annotations)
+		svc.Spec.LoadBalancerClass = kmc.Spec.Service.LoadBalancerClass
+		svc.Spec.LoadBalancerSourceRanges = kmc.Spec.Service.LoadBalancerSourceRanges
+		svc.Spec.LoadBalancerIP = kmc.Spec.Service.LoadBalancerIP
 	}
 
 	return svc
@@ -164,7 +170,11 @@ func (scope *kmcScope) reconcileServices(ctx context.Context, kmc *km.Cluster) e
 		}
 	} else if kmc.Spec.Service.Type == v1.ServiceTypeNodePort && kmc.Spec.ExternalAddress == "" {
 		logger.Info("finding nodeport address")
-		nodeAddress, err := util.FindNodeAddress(ctx, scope.client)
+		var family v1.IPFamily
+		if len(kmc.Spec.Service.IPFamilies) > 0 {
+			family = kmc.Spec.Service.IPFamilies[0]
+		}
+		nodeAddress, err := util.FindNodeAddress(ctx, scope.client, family)
 		if err != nil {
 			return fmt.Errorf("failed to find node address: %w", err)
 		}
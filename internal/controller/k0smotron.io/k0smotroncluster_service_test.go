@@ -19,10 +19,8 @@ limitations under the License.
 package k0smotronio
 
 import (
-	"encoding/json"
 	"testing"
 
-	v1beta1km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta1"
 	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
 	"github.com/k0sproject/k0smotron/v2/internal/controller/util"
 	"github.com/stretchr/testify/assert"
@@ -30,11 +28,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func mustMarshal(v any) string {
-	b, _ := json.Marshal(v)
-	return string(b)
-}
-
 func TestClusterReconciler_serviceLabels(t *testing.T) {
 	tests := []struct {
 		name string
@@ -83,11 +76,10 @@ func TestClusterReconciler_serviceLabels(t *testing.T) {
 					Labels: map[string]string{
 						"test": "test",
 					},
-					Annotations: map[string]string{
-						v1beta1km.ServiceAnnotationLabels: mustMarshal(map[string]string{"foo": "bar"}),
-					},
 				},
-				Spec: km.ClusterSpec{},
+				Spec: km.ClusterSpec{
+					Service: km.ServiceSpec{Labels: map[string]string{"foo": "bar"}},
+				},
 			},
 			want: map[string]string{
 				"app":               "k0smotron",
@@ -106,11 +98,10 @@ func TestClusterReconciler_serviceLabels(t *testing.T) {
 					Labels: map[string]string{
 						"test": "test",
 					},
-					Annotations: map[string]string{
-						v1beta1km.ServiceAnnotationLabels: mustMarshal(map[string]string{"test": "foobar"}),
-					},
 				},
-				Spec: km.ClusterSpec{},
+				Spec: km.ClusterSpec{
+					Service: km.ServiceSpec{Labels: map[string]string{"test": "foobar"}},
+				},
 			},
 			want: map[string]string{
 				"app":               "k0smotron",
@@ -167,16 +158,16 @@ func TestClusterReconciler_serviceAnnotations(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test",
 					Annotations: map[string]string{
-						"test":                                 "test",
-						v1beta1km.ServiceAnnotationAnnotations: mustMarshal(map[string]string{"foo": "bar"}),
+						"test": "test",
 					},
 				},
-				Spec: km.ClusterSpec{},
+				Spec: km.ClusterSpec{
+					Service: km.ServiceSpec{Annotations: map[string]string{"foo": "bar"}},
+				},
 			},
 			want: map[string]string{
-				"test":                                 "test",
-				v1beta1km.ServiceAnnotationAnnotations: mustMarshal(map[string]string{"foo": "bar"}),
-				"foo":                                  "bar",
+				"test": "test",
+				"foo":  "bar",
 			},
 		},
 		{
@@ -185,15 +176,15 @@ func TestClusterReconciler_serviceAnnotations(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test",
 					Annotations: map[string]string{
-						"test":                                 "test",
-						v1beta1km.ServiceAnnotationAnnotations: mustMarshal(map[string]string{"test": "foobar"}),
+						"test": "test",
 					},
 				},
-				Spec: km.ClusterSpec{},
+				Spec: km.ClusterSpec{
+					Service: km.ServiceSpec{Annotations: map[string]string{"test": "foobar"}},
+				},
 			},
 			want: map[string]string{
-				"test":                                 "foobar",
-				v1beta1km.ServiceAnnotationAnnotations: mustMarshal(map[string]string{"test": "foobar"}),
+				"test": "foobar",
 			},
 		},
 	}
@@ -227,13 +218,11 @@ func TestClusterReconciler_serviceLoadBalancerClass(t *testing.T) {
 			kmc: &km.Cluster{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test",
-					Annotations: map[string]string{
-						v1beta1km.ServiceAnnotationLoadBalancerClass: mustMarshal("class1"),
-					},
 				},
 				Spec: km.ClusterSpec{
 					Service: km.ServiceSpec{
-						Type: v1.ServiceTypeLoadBalancer,
+						Type:              v1.ServiceTypeLoadBalancer,
+						LoadBalancerClass: new("class1"),
 					},
 				},
 			},
@@ -244,13 +233,11 @@ func TestClusterReconciler_serviceLoadBalancerClass(t *testing.T) {
 			kmc: &km.Cluster{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test",
-					Annotations: map[string]string{
-						v1beta1km.ServiceAnnotationLoadBalancerClass: mustMarshal("class1"),
-					},
 				},
 				Spec: km.ClusterSpec{
 					Service: km.ServiceSpec{
-						Type: v1.ServiceTypeClusterIP,
+						Type:              v1.ServiceTypeClusterIP,
+						LoadBalancerClass: new("class1"),
 					},
 				},
 			},
@@ -265,3 +252,31 @@ func TestClusterReconciler_serviceLoadBalancerClass(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterReconciler_serviceLoadBalancerSettings(t *testing.T) {
+	kmc := &km.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: km.ClusterSpec{
+			Service: km.ServiceSpec{
+				Type:                     v1.ServiceTypeLoadBalancer,
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+				LoadBalancerIP:           "192.0.2.10",
+				ExternalTrafficPolicy:    v1.ServiceExternalTrafficPolicyLocal,
+				SessionAffinity:          v1.ServiceAffinityClientIP,
+			},
+		},
+	}
+
+	svc := generateService(kmc)
+	assert.Equal(t, []string{"10.0.0.0/8"}, svc.Spec.LoadBalancerSourceRanges)
+	assert.Equal(t, "192.0.2.10", svc.Spec.LoadBalancerIP)
+	assert.Equal(t, v1.ServiceExternalTrafficPolicyLocal, svc.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, v1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+
+	kmc.Spec.Service.Type = v1.ServiceTypeClusterIP
+	svc = generateService(kmc)
+	assert.Empty(t, svc.Spec.LoadBalancerSourceRanges)
+	assert.Empty(t, svc.Spec.LoadBalancerIP)
+	assert.Empty(t, svc.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, v1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+}
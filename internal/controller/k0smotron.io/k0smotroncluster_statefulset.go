@@ -84,7 +84,7 @@ func (scope *kmcScope) generateStatefulSet(ctx context.Context, kmc *km.Cluster)
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
-			Replicas: &kmc.Spec.Replicas,
+			Replicas: new(desiredReplicas(kmc)),
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
@@ -357,6 +357,14 @@ func (scope *kmcScope) generateStatefulSet(ctx context.Context, kmc *km.Cluster)
 		})
 	}
 
+	if hasOIDCAuthentication(kmc) {
+		addAuthenticationConfig(kmc, &statefulSet)
+	}
+
+	if kmc.Spec.Audit != nil {
+		addAudit(kmc, &statefulSet)
+	}
+
 	// Create k0s telemetry config in the configmap and mount it to the controller pod
 	// If user disables k0s telemetry this will have not effect.
 	cm := &v1.ConfigMap{
@@ -422,6 +430,17 @@ data:
 		statefulSet.Spec.Template.Spec.Containers[0].LivenessProbe.PeriodSeconds = 5
 	}
 
+	// Restart the control plane pods after a certificate rotation. The annotations are copied as the template annotations
+	// map is shared with the Cluster annotations.
+	if rotation := kmc.Status.CertificateRotation; rotation != nil && rotation.ControlPlaneRestartTime != nil {
+		templateAnnotations := maps.Clone(statefulSet.Spec.Template.Annotations)
+		if templateAnnotations == nil {
+			templateAnnotations = make(map[string]string)
+		}
+		maps.Copy(templateAnnotations, certificatesRotatedAtAnnotations(rotation.ControlPlaneRestartTime))
+		statefulSet.Spec.Template.Annotations = templateAnnotations
+	}
+
 	// Use the statefulset generated with dry-run gives us a preview of the statefulset with all the default values set by the API server.
 	// With this preview we can compare the desired statefulset with the actual statefulset and decide if there are any change and not
 	// create unnecessary new revisions of the statefulset which can make difficult get the status of the cluster from the statefulset.
@@ -545,11 +564,6 @@ func mountSecrets(kmc *km.Cluster, sfs *apps.StatefulSet) {
 		},
 	})
 
-	k0sDataVolumeName := kmc.GetVolumeName()
-	if kmc.Spec.Persistence.PersistentVolumeClaim != nil && kmc.Spec.Persistence.PersistentVolumeClaim.Name != "" {
-		k0sDataVolumeName = kmc.Spec.Persistence.PersistentVolumeClaim.Name
-	}
-
 	// We need to copy the certs from the projected volume to the /var/lib/k0s/pki directory
 	// Otherwise k0s will trip over the permissions and RO mounts
 	sfs.Spec.Template.Spec.InitContainers = append(sfs.Spec.Template.Spec.InitContainers, v1.Container{
@@ -566,13 +580,21 @@ func mountSecrets(kmc *km.Cluster, sfs *apps.StatefulSet) {
 				MountPath: "/certs-init",
 			},
 			{
-				Name:      k0sDataVolumeName,
+				Name:      k0sDataVolumeName(kmc),
 				MountPath: "/var/lib/k0s",
 			},
 		},
 	})
 }
 
+// k0sDataVolumeName returns the name of the volume mounted at /var/lib/k0s in the controller container.
+func k0sDataVolumeName(kmc *km.Cluster) string {
+	if kmc.Spec.Persistence.PersistentVolumeClaim != nil && kmc.Spec.Persistence.PersistentVolumeClaim.Name != "" {
+		return kmc.Spec.Persistence.PersistentVolumeClaim.Name
+	}
+	return kmc.GetVolumeName()
+}
+
 // pathToVolumeName converts a mount path to a valid Kubernetes volume name (DNS label).
 // For paths that already produce a valid name with the simple transformation (strip leading
 // slash, replace slashes with dashes), that name is returned unchanged for backward
@@ -690,6 +712,11 @@ func (scope *kmcScope) reconcileStatefulSet(ctx context.Context, kmc *km.Cluster
 		return ctrl.Result{}, fmt.Errorf("failed to generate statefulset: %w", err)
 	}
 
+	if scope.currentReconcileState.etcdResuming {
+		// Hold back the control plane replicas until etcd has quorum again.
+		statefulSet.Spec.Replicas = new(int32(0))
+	}
+
 	selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error retrieving StatefulSet labels: %w", err)
@@ -707,6 +734,11 @@ func (scope *kmcScope) reconcileStatefulSet(ctx context.Context, kmc *km.Cluster
 	} else if err == nil {
 		scope.currentReconcileState.controlplane.sts = foundStatefulSet.DeepCopy()
 		detectAndSetCurrentClusterVersion(foundStatefulSet, kmc)
+
+		rotation := kmc.Status.CertificateRotation
+		if rotation != nil && rotation.Phase == km.CertificateRotationPhaseRestartingControlPlane && isStatefulSetRolledOut(foundStatefulSet, rotation.ControlPlaneRestartTime) {
+			rotation.Phase = km.CertificateRotationPhaseCompleted
+		}
 	}
 
 	if !isStatefulSetsEqual(&statefulSetPreview, foundStatefulSet) {
@@ -719,7 +751,7 @@ func (scope *kmcScope) reconcileStatefulSet(ctx context.Context, kmc *km.Cluster
 		return ctrl.Result{}, scope.client.Patch(ctx, patchedFoundStatefulSet, client.MergeFrom(foundStatefulSet)) //nolint:forbidigo // replica scaling via MergeFrom, patches not applicable
 	}
 
-	if foundStatefulSet.Status.ReadyReplicas == 0 {
+	if foundStatefulSet.Status.ReadyReplicas == 0 && *statefulSet.Spec.Replicas > 0 {
 		logger.Info(fmt.Sprintf("StatefulSet '%s' has no ready replicas yet (%d/%d)", foundStatefulSet.GetName(), foundStatefulSet.Status.ReadyReplicas, kmc.Spec.Replicas))
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Minute}, nil
 	}
@@ -727,6 +759,15 @@ func (scope *kmcScope) reconcileStatefulSet(ctx context.Context, kmc *km.Cluster
 	return ctrl.Result{}, nil
 }
 
+// desiredReplicas returns the number of control plane replicas to run, which is zero while the cluster is suspended.
+// The NATS cluster size is still derived from spec.replicas so that the cluster comes back with the same peers.
+func desiredReplicas(kmc *km.Cluster) int32 {
+	if kmc.Spec.Suspended {
+		return 0
+	}
+	return kmc.Spec.Replicas
+}
+
 // If the version is empty from the spec, we try to detect it from the statefulset image.
 func detectAndSetCurrentClusterVersion(foundStatefulSet *apps.StatefulSet, kmc *km.Cluster) {
 	if kmc.Spec.Version == "" {
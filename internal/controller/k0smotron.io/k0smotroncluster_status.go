@@ -23,6 +23,8 @@ import (
 
 	"github.com/cloudflare/cfssl/log"
 	k0smotroniov1beta2 "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+	kutil "github.com/k0sproject/k0smotron/v2/internal/controller/util"
+	"github.com/k0sproject/k0smotron/v2/internal/metrics"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -42,7 +44,10 @@ func (r *ClusterReconciler) updateStatus(ctx context.Context, kmc *k0smotroniov1
 	setControlPlaneExposedCondition(kmc, scope.controlplane.svc)
 	setControlPlaneKubeconfigAvailableCondition(kmc, scope.controlplane)
 	setControlPlaneFunctionalCondition(ctx, r.Client, kmc, scope.controlplane.sts)
+	setSuspendedCondition(kmc, scope)
 	setCertificatesAvailableCondition(kmc)
+	kutil.SetCertificatesExpiringSoonCondition(kmc, kmc.Status.Certificates, r.CertificateExpiryWarningWindow, time.Now())
+	metrics.SetCertificateExpiry("Cluster", kmc.Namespace, kmc.Name, kmc.Status.Certificates)
 	setDeletingCondition(kmc, scope.reason, scope.message)
 	setAvailableCondition(kmc)
 }
@@ -61,6 +66,16 @@ func translateStatusFromStatefulSet(kmc *k0smotroniov1beta2.Cluster, sts *apps.S
 }
 
 func setAvailableCondition(kmc *k0smotroniov1beta2.Cluster) {
+	if kmc.Spec.Suspended {
+		conditions.Set(kmc, metav1.Condition{
+			Type:    k0smotroniov1beta2.ClusterAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  k0smotroniov1beta2.ClusterSuspendedReason,
+			Message: "Control plane is suspended",
+		})
+		return
+	}
+
 	summaryOpts := []conditions.SummaryOption{
 		conditions.ForConditionTypes{
 			k0smotroniov1beta2.ClusterControlPlaneFunctionalCondition,
@@ -106,7 +121,10 @@ func setCertificatesAvailableCondition(kmc *k0smotroniov1beta2.Cluster) {
 func setControlPlaneKubeconfigAvailableCondition(kmc *k0smotroniov1beta2.Cluster, controlPlaneState controlplaneState) {
 	reason := k0smotroniov1beta2.InternalErrorReason
 	if controlPlaneState.kubeconfig.message == "" {
-		if controlPlaneState.sts == nil {
+		if kmc.Spec.Suspended {
+			controlPlaneState.kubeconfig.message = "Control plane is suspended, the kubeconfig secret is validated again on resume"
+			reason = k0smotroniov1beta2.ClusterSuspendedReason
+		} else if controlPlaneState.sts == nil {
 			controlPlaneState.kubeconfig.message = "Control plane StatefulSet not found"
 		} else if controlPlaneState.sts.Status.ReadyReplicas == 0 {
 			controlPlaneState.kubeconfig.message = "It is needed to have at least 1 ready replica to generate the workload kubeconfig"
@@ -173,42 +191,44 @@ func setControlPlaneScalingCondition(kmc *k0smotroniov1beta2.Cluster, sts *apps.
 		return
 	}
 
-	if kmc.Spec.Replicas > sts.Status.Replicas {
+	replicas := desiredReplicas(kmc)
+
+	if replicas > sts.Status.Replicas {
 		conditions.Set(kmc, metav1.Condition{
 			Type:    k0smotroniov1beta2.ClusterControlPlaneScalingCondition,
 			Status:  metav1.ConditionTrue,
 			Reason:  k0smotroniov1beta2.ClusterControlPlaneScaledUpReason,
-			Message: fmt.Sprintf("Control plane is scaling up: %d/%d replicas required", sts.Status.Replicas, kmc.Spec.Replicas),
+			Message: fmt.Sprintf("Control plane is scaling up: %d/%d replicas required", sts.Status.Replicas, replicas),
 		})
 		return
 	}
 
-	if sts.Status.Replicas > kmc.Spec.Replicas {
+	if sts.Status.Replicas > replicas {
 		conditions.Set(kmc, metav1.Condition{
 			Type:    k0smotroniov1beta2.ClusterControlPlaneScalingCondition,
 			Status:  metav1.ConditionTrue,
 			Reason:  k0smotroniov1beta2.ClusterControlPlaneScaledDownReason,
-			Message: fmt.Sprintf("Control plane is scaling down: %d/%d replicas desired", sts.Status.Replicas, kmc.Spec.Replicas),
+			Message: fmt.Sprintf("Control plane is scaling down: %d/%d replicas desired", sts.Status.Replicas, replicas),
 		})
 		return
 	}
 
-	if sts.Status.UpdatedReplicas < kmc.Spec.Replicas {
+	if sts.Status.UpdatedReplicas < replicas {
 		conditions.Set(kmc, metav1.Condition{
 			Type:    k0smotroniov1beta2.ClusterControlPlaneScalingCondition,
 			Status:  metav1.ConditionTrue,
 			Reason:  k0smotroniov1beta2.ClusterControlPlaneScaledUpReason,
-			Message: fmt.Sprintf("Control plane is scaling up: %d/%d replicas created", sts.Status.UpdatedReplicas, kmc.Spec.Replicas),
+			Message: fmt.Sprintf("Control plane is scaling up: %d/%d replicas created", sts.Status.UpdatedReplicas, replicas),
 		})
 		return
 	}
 
-	if sts.Status.UpdatedReplicas > kmc.Spec.Replicas {
+	if sts.Status.UpdatedReplicas > replicas {
 		conditions.Set(kmc, metav1.Condition{
 			Type:    k0smotroniov1beta2.ClusterControlPlaneScalingCondition,
 			Status:  metav1.ConditionTrue,
 			Reason:  k0smotroniov1beta2.ClusterControlPlaneScaledDownReason,
-			Message: fmt.Sprintf("Control plane is scaling down: %d/%d replicas desired", sts.Status.UpdatedReplicas, kmc.Spec.Replicas),
+			Message: fmt.Sprintf("Control plane is scaling down: %d/%d replicas desired", sts.Status.UpdatedReplicas, replicas),
 		})
 		return
 	}
@@ -231,12 +251,12 @@ func setControlPlaneUpToDateCondition(kmc *k0smotroniov1beta2.Cluster, sts *apps
 		return
 	}
 
-	if kmc.Spec.Replicas != sts.Status.UpdatedReplicas {
+	if replicas := desiredReplicas(kmc); replicas != sts.Status.UpdatedReplicas {
 		conditions.Set(kmc, metav1.Condition{
 			Type:    k0smotroniov1beta2.ClusterControlPlaneUpToDateCondition,
 			Status:  metav1.ConditionFalse,
 			Reason:  k0smotroniov1beta2.ClusterControlPlaneNotAllReplicasUpToDateReason,
-			Message: fmt.Sprintf("Control plane is updating: %d/%d replicas with desired template", sts.Status.UpdatedReplicas, kmc.Spec.Replicas),
+			Message: fmt.Sprintf("Control plane is updating: %d/%d replicas with desired template", sts.Status.UpdatedReplicas, replicas),
 		})
 	} else {
 		conditions.Set(kmc, metav1.Condition{
@@ -258,6 +278,17 @@ func setControlPlaneFunctionalCondition(ctx context.Context, c client.Client, km
 		return
 	}
 
+	// A suspended control plane is not reachable on purpose, there is no point in trying to connect to it.
+	if kmc.Spec.Suspended {
+		conditions.Set(kmc, metav1.Condition{
+			Type:    k0smotroniov1beta2.ClusterControlPlaneFunctionalCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  k0smotroniov1beta2.ClusterSuspendedReason,
+			Message: "Control plane is suspended",
+		})
+		return
+	}
+
 	if !conditions.IsTrue(kmc, k0smotroniov1beta2.ClusterKubeconfigSecretAvailableCondition) {
 		conditions.Set(kmc, metav1.Condition{
 			Type:    k0smotroniov1beta2.ClusterControlPlaneFunctionalCondition,
@@ -315,6 +346,50 @@ func setControlPlaneFunctionalCondition(ctx context.Context, c client.Client, km
 	})
 }
 
+func setSuspendedCondition(kmc *k0smotroniov1beta2.Cluster, state currentReconcileState) {
+	if kmc.Spec.Suspended {
+		if sts := state.controlplane.sts; sts != nil && sts.Status.Replicas > 0 {
+			conditions.Set(kmc, metav1.Condition{
+				Type:    k0smotroniov1beta2.ClusterSuspendedCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  k0smotroniov1beta2.ClusterSuspendingReason,
+				Message: fmt.Sprintf("Control plane is scaling down: %d replicas left", sts.Status.Replicas),
+			})
+			return
+		}
+		conditions.Set(kmc, metav1.Condition{
+			Type:   k0smotroniov1beta2.ClusterSuspendedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: k0smotroniov1beta2.ClusterSuspendedReason,
+		})
+		return
+	}
+
+	// The cluster is resuming until the control plane is functional again, starting from the first reconciliation
+	// after spec.suspended has been unset.
+	previous := conditions.Get(kmc, k0smotroniov1beta2.ClusterSuspendedCondition)
+	wasSuspended := previous != nil && (previous.Status == metav1.ConditionTrue || previous.Reason == k0smotroniov1beta2.ClusterResumingReason)
+	if wasSuspended && (state.etcdResuming || !conditions.IsTrue(kmc, k0smotroniov1beta2.ClusterControlPlaneFunctionalCondition)) {
+		message := "Waiting for the control plane to be functional"
+		if state.etcdResuming {
+			message = "Waiting for etcd to be ready"
+		}
+		conditions.Set(kmc, metav1.Condition{
+			Type:    k0smotroniov1beta2.ClusterSuspendedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  k0smotroniov1beta2.ClusterResumingReason,
+			Message: message,
+		})
+		return
+	}
+
+	conditions.Set(kmc, metav1.Condition{
+		Type:   k0smotroniov1beta2.ClusterSuspendedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: k0smotroniov1beta2.ClusterNotSuspendedReason,
+	})
+}
+
 func setDeletingCondition(kmc *k0smotroniov1beta2.Cluster, reason, message string) {
 	if kmc.DeletionTimestamp.IsZero() {
 		conditions.Set(kmc, metav1.Condition{
@@ -0,0 +1,129 @@
+//go:build !envtest
+
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k0smotronio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+func TestSetSuspendedCondition(t *testing.T) {
+	stsWithReplicas := func(replicas int32) *apps.StatefulSet {
+		return &apps.StatefulSet{Status: apps.StatefulSetStatus{Replicas: replicas}}
+	}
+
+	tests := []struct {
+		name       string
+		suspended  bool
+		previous   *metav1.Condition
+		functional bool
+		state      currentReconcileState
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "suspending",
+			suspended:  true,
+			state:      currentReconcileState{controlplane: controlplaneState{sts: stsWithReplicas(2)}},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: km.ClusterSuspendingReason,
+		},
+		{
+			name:       "suspended",
+			suspended:  true,
+			state:      currentReconcileState{controlplane: controlplaneState{sts: stsWithReplicas(0)}},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: km.ClusterSuspendedReason,
+		},
+		{
+			name:       "resuming etcd",
+			previous:   &metav1.Condition{Status: metav1.ConditionTrue, Reason: km.ClusterSuspendedReason},
+			state:      currentReconcileState{etcdResuming: true},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: km.ClusterResumingReason,
+		},
+		{
+			name:       "resuming control plane",
+			previous:   &metav1.Condition{Status: metav1.ConditionFalse, Reason: km.ClusterResumingReason},
+			state:      currentReconcileState{controlplane: controlplaneState{sts: stsWithReplicas(3)}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: km.ClusterResumingReason,
+		},
+		{
+			name:       "resumed",
+			previous:   &metav1.Condition{Status: metav1.ConditionFalse, Reason: km.ClusterResumingReason},
+			functional: true,
+			state:      currentReconcileState{controlplane: controlplaneState{sts: stsWithReplicas(3)}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: km.ClusterNotSuspendedReason,
+		},
+		{
+			name:       "never suspended",
+			state:      currentReconcileState{controlplane: controlplaneState{sts: stsWithReplicas(0)}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: km.ClusterNotSuspendedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kmc := &km.Cluster{Spec: km.ClusterSpec{Suspended: tt.suspended}}
+			if tt.previous != nil {
+				tt.previous.Type = km.ClusterSuspendedCondition
+				conditions.Set(kmc, *tt.previous)
+			}
+			functional := metav1.ConditionFalse
+			if tt.functional {
+				functional = metav1.ConditionTrue
+			}
+			conditions.Set(kmc, metav1.Condition{
+				Type:   km.ClusterControlPlaneFunctionalCondition,
+				Status: functional,
+				Reason: km.ClusterControlPlaneFunctionalReason,
+			})
+
+			setSuspendedCondition(kmc, tt.state)
+
+			c := conditions.Get(kmc, km.ClusterSuspendedCondition)
+			require.NotNil(t, c)
+			assert.Equal(t, tt.wantStatus, c.Status)
+			assert.Equal(t, tt.wantReason, c.Reason)
+		})
+	}
+}
+
+func TestSuspendedClusterConditions(t *testing.T) {
+	kmc := &km.Cluster{Spec: km.ClusterSpec{Replicas: 3, Suspended: true}}
+	sts := &apps.StatefulSet{}
+
+	setControlPlaneScalingCondition(kmc, sts)
+	setControlPlaneUpToDateCondition(kmc, sts)
+	setAvailableCondition(kmc)
+
+	assert.True(t, conditions.IsFalse(kmc, km.ClusterControlPlaneScalingCondition))
+	assert.True(t, conditions.IsTrue(kmc, km.ClusterControlPlaneUpToDateCondition))
+	assert.True(t, conditions.IsFalse(kmc, km.ClusterAvailableCondition))
+	assert.Equal(t, km.ClusterSuspendedReason, conditions.GetReason(kmc, km.ClusterAvailableCondition))
+}
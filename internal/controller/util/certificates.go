@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cfssl/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+// DefaultCertificateExpiryWarningWindow is the default time before expiry after which certificates are reported as expiring soon.
+const DefaultCertificateExpiryWarningWindow = 30 * 24 * time.Hour
+
+// CertificateStatuses returns the status of the given certificates of a cluster, sorted by purpose. Certificates that are not
+// loaded or cannot be parsed, such as the service account key pair, are skipped.
+func CertificateStatuses(clusterName string, certificates secret.Certificates) []km.CertificateStatus {
+	var statuses []km.CertificateStatus
+	for _, c := range certificates {
+		if c.KeyPair == nil || len(c.KeyPair.Cert) == 0 {
+			continue
+		}
+		cert, err := helpers.ParseCertificatePEM(c.KeyPair.Cert)
+		if err != nil {
+			continue
+		}
+		statuses = append(statuses, km.CertificateStatus{
+			Purpose:    string(c.Purpose),
+			SecretName: secret.Name(clusterName, c.Purpose),
+			NotAfter:   metav1.NewTime(cert.NotAfter),
+		})
+	}
+	slices.SortFunc(statuses, func(a, b km.CertificateStatus) int {
+		return strings.Compare(a.Purpose, b.Purpose)
+	})
+	return statuses
+}
+
+// CertificatesExpiringSoon returns a message listing the certificates expiring within the given window, or an empty
+// string if there are none.
+func CertificatesExpiringSoon(statuses []km.CertificateStatus, window time.Duration, now time.Time) string {
+	var expiring []string
+	for _, s := range statuses {
+		if now.Add(window).After(s.NotAfter.Time) {
+			expiring = append(expiring, fmt.Sprintf("%s expires at %s", s.Purpose, s.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	return strings.Join(expiring, "; ")
+}
+
+// SetCertificatesExpiringSoonCondition sets the CertificatesExpiringSoon condition of the object based on the given
+// certificates. A zero window means the default warning window.
+func SetCertificatesExpiringSoonCondition(obj conditions.Setter, statuses []km.CertificateStatus, window time.Duration, now time.Time) {
+	if window == 0 {
+		window = DefaultCertificateExpiryWarningWindow
+	}
+
+	if expiring := CertificatesExpiringSoon(statuses, window, now); expiring != "" {
+		conditions.Set(obj, metav1.Condition{
+			Type:    km.ClusterCertificatesExpiringSoonCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  km.CertificatesExpiringSoonReason,
+			Message: expiring,
+		})
+		return
+	}
+
+	conditions.Set(obj, metav1.Condition{
+		Type:   km.ClusterCertificatesExpiringSoonCondition,
+		Status: metav1.ConditionFalse,
+		Reason: km.CertificatesNotExpiringSoonReason,
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+	"github.com/k0sproject/k0smotron/v2/internal/test/certificates"
+)
+
+func TestCertificateStatuses(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	statuses := CertificateStatuses("test", secret.Certificates{
+		&secret.Certificate{Purpose: secret.EtcdCA, KeyPair: certificates.NewKeyPair(t, notAfter)},
+		&secret.Certificate{Purpose: secret.ClusterCA, KeyPair: certificates.NewKeyPair(t, notAfter)},
+		&secret.Certificate{Purpose: secret.ServiceAccount, KeyPair: &certs.KeyPair{Cert: []byte("not a certificate")}},
+		&secret.Certificate{Purpose: "etcd-peer"},
+	})
+
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "ca", statuses[0].Purpose)
+	assert.Equal(t, "test-ca", statuses[0].SecretName)
+	assert.Equal(t, "etcd", statuses[1].Purpose)
+	assert.Equal(t, "test-etcd", statuses[1].SecretName)
+	assert.True(t, notAfter.Equal(statuses[1].NotAfter.Time))
+}
+
+func TestCertificatesExpiringSoon(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	statuses := []km.CertificateStatus{
+		{Purpose: "ca", NotAfter: metav1.NewTime(now.Add(365 * 24 * time.Hour))},
+		{Purpose: "etcd-server", NotAfter: metav1.NewTime(now.Add(10 * 24 * time.Hour))},
+	}
+
+	assert.Equal(t, "etcd-server expires at 2026-01-11T00:00:00Z", CertificatesExpiringSoon(statuses, DefaultCertificateExpiryWarningWindow, now))
+	assert.Empty(t, CertificatesExpiringSoon(statuses, 24*time.Hour, now))
+	assert.Empty(t, CertificatesExpiringSoon(nil, DefaultCertificateExpiryWarningWindow, now))
+}
+
+func TestSetCertificatesExpiringSoonCondition(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	kmc := &km.Cluster{}
+	statuses := []km.CertificateStatus{{Purpose: "etcd-server", NotAfter: metav1.NewTime(now.Add(10 * 24 * time.Hour))}}
+
+	SetCertificatesExpiringSoonCondition(kmc, statuses, 0, now)
+	c := conditions.Get(kmc, km.ClusterCertificatesExpiringSoonCondition)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, km.CertificatesExpiringSoonReason, c.Reason)
+	assert.Equal(t, "etcd-server expires at 2026-01-11T00:00:00Z", c.Message)
+
+	SetCertificatesExpiringSoonCondition(kmc, statuses, 24*time.Hour, now)
+	c = conditions.Get(kmc, km.ClusterCertificatesExpiringSoonCondition)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, km.CertificatesNotExpiringSoonReason, c.Reason)
+}
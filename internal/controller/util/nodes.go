@@ -17,13 +17,15 @@ package util
 
 import (
 	"context"
+	"net"
 
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// FindNodeAddress returns a random node address preferring external address if one is found
-func FindNodeAddress(ctx context.Context, client client.Client) (string, error) {
+// FindNodeAddress returns a random node address preferring external address if one is found. If family is set, only
+// the IP addresses of that family are considered.
+func FindNodeAddress(ctx context.Context, client client.Client, family v1.IPFamily) (string, error) {
 	var internalAddress string
 
 	// Get a random node address as external address
@@ -35,6 +37,10 @@ func FindNodeAddress(ctx context.Context, client client.Client) (string, error)
 
 	for _, node := range nodes.Items {
 		for _, addr := range node.Status.Addresses {
+			if addr.Type != v1.NodeExternalDNS && !isIPFamily(addr.Address, family) {
+				continue
+			}
+
 			if internalAddress == "" && addr.Type == v1.NodeInternalIP {
 				internalAddress = addr.Address
 			}
@@ -48,3 +54,18 @@ func FindNodeAddress(ctx context.Context, client client.Client) (string, error)
 	// Return internal address if no external address was found for any node
 	return internalAddress, nil
 }
+
+// isIPFamily returns true if the address is an IP of the given family, or if no family is given.
+func isIPFamily(address string, family v1.IPFamily) bool {
+	if family == "" {
+		return true
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	if ip.To4() != nil {
+		return family == v1.IPv4Protocol
+	}
+	return family == v1.IPv6Protocol
+}
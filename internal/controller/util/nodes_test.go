@@ -28,9 +28,10 @@ import (
 func TestFindNodeAddress(t *testing.T) {
 
 	tests := []struct {
-		name  string
-		nodes *v1.NodeList
-		want  string
+		name   string
+		nodes  *v1.NodeList
+		family v1.IPFamily
+		want   string
 	}{
 		{
 			name: "when only internal is set",
@@ -142,6 +143,33 @@ func TestFindNodeAddress(t *testing.T) {
 			},
 			want: "1.1.1.1",
 		},
+		{
+			name: "when the IPv6 family is requested",
+			nodes: &v1.NodeList{
+				Items: []v1.Node{
+					{
+						Status: v1.NodeStatus{
+							Addresses: []v1.NodeAddress{
+								{
+									Type:    v1.NodeExternalIP,
+									Address: "1.1.1.1",
+								},
+								{
+									Type:    v1.NodeInternalIP,
+									Address: "fd00::2",
+								},
+								{
+									Type:    v1.NodeExternalIP,
+									Address: "2001:db8::1",
+								},
+							},
+						},
+					},
+				},
+			},
+			family: v1.IPv6Protocol,
+			want:   "2001:db8::1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,7 +181,7 @@ func TestFindNodeAddress(t *testing.T) {
 				WithObjects(&tt.nodes.Items[0]).
 				Build()
 
-			got, err := FindNodeAddress(context.Background(), client)
+			got, err := FindNodeAddress(context.Background(), client, tt.family)
 			require.NoError(t, err)
 			require.Equal(t, tt.want, got)
 		})
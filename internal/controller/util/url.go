@@ -0,0 +1,61 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ServerURL returns the https URL of an API server, bracketing the host if it is an IPv6 address.
+func ServerURL(host string, port int64) string {
+	return "https://" + net.JoinHostPort(host, strconv.FormatInt(port, 10))
+}
+
+// NormalizeServerURL brackets the host of the server URL if it is an unbracketed IPv6 address, as in
+// "https://2001:db8::1". An unbracketed IPv6 address followed by a port is only accepted if the port can't be read as
+// part of the address, as in "https://2001:db8:0:0:0:0:0:1:6443". Otherwise, as in "https://fd00::1:6443", the URL is
+// ambiguous and rejected.
+func NormalizeServerURL(server string) (string, error) {
+	scheme, hostPort, found := strings.Cut(server, "://")
+	if !found || strings.Contains(hostPort, "[") {
+		return server, nil
+	}
+	var path string
+	if i := strings.Index(hostPort, "/"); i >= 0 {
+		hostPort, path = hostPort[:i], hostPort[i:]
+	}
+	if strings.Count(hostPort, ":") < 2 {
+		return server, nil
+	}
+
+	isIP := net.ParseIP(hostPort) != nil
+	i := strings.LastIndex(hostPort, ":")
+	_, err := strconv.ParseUint(hostPort[i+1:], 10, 16)
+	hasPort := err == nil && net.ParseIP(hostPort[:i]) != nil
+	switch {
+	case isIP && hasPort:
+		return "", fmt.Errorf("ambiguous server URL %q, the IPv6 address must be bracketed", server)
+	case isIP:
+		return scheme + "://[" + hostPort + "]" + path, nil
+	case hasPort:
+		return scheme + "://" + net.JoinHostPort(hostPort[:i], hostPort[i+1:]) + path, nil
+	}
+	return server, nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerURL(t *testing.T) {
+	require.Equal(t, "https://10.0.0.1:6443", ServerURL("10.0.0.1", 6443))
+	require.Equal(t, "https://[fd00::1]:6443", ServerURL("fd00::1", 6443))
+}
+
+func TestNormalizeServerURL(t *testing.T) {
+	tests := []struct {
+		server  string
+		want    string
+		wantErr bool
+	}{
+		{server: "https://10.0.0.1:6443", want: "https://10.0.0.1:6443"},
+		{server: "https://[fd00::1]:6443", want: "https://[fd00::1]:6443"},
+		{server: "https://2001:db8::1/api", want: "https://[2001:db8::1]/api"},
+		{server: "https://2001:db8:0:0:0:0:0:1:6443", want: "https://[2001:db8:0:0:0:0:0:1]:6443"},
+		// The port could also be the last group of the address.
+		{server: "https://fd00::1:6443", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.server, func(t *testing.T) {
+			got, err := NormalizeServerURL(tt.server)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
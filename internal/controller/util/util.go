@@ -33,18 +33,20 @@ const ComponentLabel = "app.kubernetes.io/component"
 // Component label values for app.kubernetes.io/component (and legacy "component").
 // Use these constants so component names are defined in one place.
 const (
-	ComponentBootstrap     = "bootstrap"
-	ComponentClusterConfig = "cluster-config"
-	ComponentConfig        = "config"
-	ComponentControlPlane  = "control-plane"
-	ComponentEntrypoint    = "entrypoint"
-	ComponentEtcd          = "etcd"
-	ComponentIngress       = "ingress"
-	ComponentJointoken     = "jointoken"
-	ComponentKubeconfig    = "kubeconfig"
-	ComponentMonitoring    = "monitoring"
-	ComponentTelemetry     = "telemetry"
-	ComponentTunneling     = "tunneling"
+	ComponentAuthentication = "authentication"
+	ComponentAutoscaling    = "autoscaling"
+	ComponentBootstrap      = "bootstrap"
+	ComponentClusterConfig  = "cluster-config"
+	ComponentConfig         = "config"
+	ComponentControlPlane   = "control-plane"
+	ComponentEntrypoint     = "entrypoint"
+	ComponentEtcd           = "etcd"
+	ComponentIngress        = "ingress"
+	ComponentJointoken      = "jointoken"
+	ComponentKubeconfig     = "kubeconfig"
+	ComponentMonitoring     = "monitoring"
+	ComponentTelemetry      = "telemetry"
+	ComponentTunneling      = "tunneling"
 )
 
 // DefaultK0smotronClusterLabels returns the default labels (app, cluster).
@@ -0,0 +1,51 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains the Prometheus metrics exported on the manager's metrics endpoint.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	km "github.com/k0sproject/k0smotron/v2/api/k0smotron.io/v1beta2"
+)
+
+// CertificateExpirationTimestamp exposes the expiry date of the certificates managed by k0smotron as a unix timestamp.
+var CertificateExpirationTimestamp = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "k0smotron_certificate_expiration_timestamp_seconds",
+		Help: "Expiry date of a certificate managed by k0smotron, as a unix timestamp in seconds.",
+	},
+	[]string{"kind", "namespace", "name", "purpose", "secret"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(CertificateExpirationTimestamp)
+}
+
+// SetCertificateExpiry replaces the certificate expiry gauges of the given object with the given certificate statuses.
+func SetCertificateExpiry(kind, namespace, name string, statuses []km.CertificateStatus) {
+	DeleteCertificateExpiry(kind, namespace, name)
+	for _, s := range statuses {
+		CertificateExpirationTimestamp.WithLabelValues(kind, namespace, name, s.Purpose, s.SecretName).Set(float64(s.NotAfter.Unix()))
+	}
+}
+
+// DeleteCertificateExpiry removes the certificate expiry gauges of the given object.
+func DeleteCertificateExpiry(kind, namespace, name string) {
+	CertificateExpirationTimestamp.DeletePartialMatch(prometheus.Labels{"kind": kind, "namespace": namespace, "name": name})
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	maxNameLength          = 63
+	randomLength           = 5
+	maxGeneratedNameLength = maxNameLength - randomLength
+)
+
+// ControlPlaneMachineName renders a machine name template for a control plane machine. The template can use
+// `.cluster.name`, `.k0sControlPlane.name` and `.random`. Names longer than 63 characters are trimmed to 58
+// characters and get a random suffix of 5 characters, the same way upstream Cluster API does it.
+func ControlPlaneMachineName(nameTemplate, clusterName, controlPlaneName string) (string, error) {
+	tpl, err := template.New("machine name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parsing template %q: %w", nameTemplate, err)
+	}
+
+	data := map[string]any{
+		"cluster": map[string]any{
+			"name": clusterName,
+		},
+		"k0sControlPlane": map[string]any{
+			"name": controlPlaneName,
+		},
+		"random": utilrand.String(randomLength),
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering template %q: %w", nameTemplate, err)
+	}
+
+	name := buf.String()
+	if len(name) > maxNameLength {
+		name = name[:maxGeneratedNameLength] + utilrand.String(randomLength)
+	}
+
+	return name, nil
+}